@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/spf13/cobra"
+
+	pb "keyayun.com/seal-micro-runner/pkg/proto"
+	"keyayun.com/seal-micro-runner/pkg/services"
+)
+
+var (
+	callService string
+	callNode    string
+	callData    string
+	callFields  []string
+	callURL     string
+)
+
+// callRequests call支持的方法及其请求类型
+var callRequests = map[string]func() interface{}{
+	"manifest": func() interface{} { return &pb.ManifestRequest{} },
+	"register": func() interface{} { return &pb.TokenModel{} },
+	"start":    func() interface{} { return &pb.StartRequest{} },
+	"stop":     func() interface{} { return &pb.StopRequest{} },
+	"stream":   func() interface{} { return &pb.StreamData{} },
+}
+
+// setField 按json名设置请求字段, 只支持字符串, 整数和布尔字段
+func setField(req interface{}, name, value string) error {
+	v := reflect.ValueOf(req).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if strings.Split(t.Field(i).Tag.Get("json"), ",")[0] != name {
+			continue
+		}
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.String:
+			f.SetString(value)
+			return nil
+		case reflect.Int32, reflect.Int64:
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("field %s: %v", name, err)
+			}
+			f.SetInt(n)
+			return nil
+		case reflect.Bool:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("field %s: %v", name, err)
+			}
+			f.SetBool(b)
+			return nil
+		}
+		return fmt.Errorf("field %s of %s can not be set with -f, use --data", name, t.Name())
+	}
+	return fmt.Errorf("%s has no field %s", t.Name(), name)
+}
+
+// buildRequest 依次应用--data, -f和--url
+func buildRequest(req interface{}) error {
+	if callData != "" {
+		b := []byte(callData)
+		if strings.HasPrefix(callData, "@") {
+			var err error
+			b, err = ioutil.ReadFile(callData[1:])
+			if err != nil {
+				return err
+			}
+		}
+		err := json.Unmarshal(b, req)
+		if err != nil {
+			return fmt.Errorf("bad request body: %v", err)
+		}
+	}
+	for _, field := range callFields {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("bad field %q, want name=value", field)
+		}
+		err := setField(req, kv[0], kv[1])
+		if err != nil {
+			return err
+		}
+	}
+	if callURL == "" {
+		return nil
+	}
+	u, err := url.Parse(callURL)
+	if err != nil {
+		return err
+	}
+	// Start返回的url中_id为render的serverID, 请求需要发到同一个node
+	query := u.Query()
+	if callNode == "" {
+		callNode = query.Get("_id")
+	}
+	for _, name := range []string{"_sid", "_exp", "_sig", "role"} {
+		if value := query.Get(name); value != "" {
+			err = setField(req, name, value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var callCmd = &cobra.Command{
+	Use:   "call <manifest|register|start|stop|stream>",
+	Short: "call the Services API of a registered service",
+	Long: `call resolves the service through the configured registry and prints the response as json.
+The request body is given with --data as json or @file, then overridden by -f name=value
+using the json field names, e.g. -f workItemID=1.2.3 -f viewports=2.
+--url takes a stream, observe or stop url returned by start and fills _sid, _exp, _sig, role and the node.
+stream sends every stdin line as a text frame and prints the frames it receives.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return cmd.Usage()
+		}
+		method := strings.ToLower(args[0])
+		newRequest, ok := callRequests[method]
+		if !ok {
+			return cmd.Usage()
+		}
+		req := newRequest()
+		err := buildRequest(req)
+		if err != nil {
+			return err
+		}
+		name := fmt.Sprintf("%s.%s", conf.GetString("task.prefix"), callService)
+		srv, reg, err := servicesClient(name)
+		if err != nil {
+			return err
+		}
+		nodes, err := serviceNodes(reg, name, callNode)
+		if err != nil {
+			return err
+		}
+		var opts []client.CallOption
+		if callNode != "" {
+			opts = append(opts, client.WithAddress(nodes[0].Address))
+		}
+		if method == "stream" {
+			return callStream(srv, req.(*pb.StreamData), opts)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+		defer cancel()
+		var rsp interface{}
+		switch r := req.(type) {
+		case *pb.ManifestRequest:
+			rsp, err = srv.Manifest(ctx, r, opts...)
+		case *pb.TokenModel:
+			rsp, err = srv.Register(ctx, r, opts...)
+		case *pb.StartRequest:
+			rsp, err = srv.Start(ctx, r, opts...)
+		case *pb.StopRequest:
+			rsp, err = srv.Stop(ctx, r, opts...)
+		}
+		if err != nil {
+			return err
+		}
+		return printJSON(rsp)
+	},
+}
+
+// callStream 交互式Stream, stdin的每一行作为文本帧发送, 收到的帧打印到stdout
+func callStream(srv pb.ServicesService, first *pb.StreamData, opts []client.CallOption) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := srv.Stream(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	var sendMu sync.Mutex
+	send := func(data *pb.StreamData) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return stream.Send(data)
+	}
+	err = send(first)
+	if err != nil {
+		return err
+	}
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			err := send(&pb.StreamData{XSid: first.XSid, Data: []byte(scanner.Text()), MessageType: websocket.TextMessage})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "send failed: %v\n", err)
+				return
+			}
+		}
+		send(&pb.StreamData{XSid: first.XSid, MessageType: websocket.CloseMessage, CloseCode: websocket.CloseNormalClosure})
+	}()
+	for {
+		data, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch {
+		case data.Heartbeat:
+			// 回应心跳, 否则空闲的终端会被服务端当作断开
+			err = send(&pb.StreamData{XSid: first.XSid, Heartbeat: true})
+			if err != nil {
+				return err
+			}
+		case data.Role != "":
+			fmt.Printf("< role %s\n", data.Role)
+		case data.MessageType == websocket.CloseMessage:
+			fmt.Printf("< close %d %s\n", data.CloseCode, data.CloseText)
+			return nil
+		case data.MessageType == websocket.BinaryMessage:
+			fmt.Printf("< binary %d bytes\n", len(data.Data))
+		default:
+			fmt.Printf("< %s\n", data.Data)
+		}
+	}
+}
+
+func init() {
+	flags := callCmd.Flags()
+	flags.StringVar(&callService, "service", services.CarsRender, "service name without the task prefix")
+	flags.StringVar(&callNode, "node", "", "node id, server id or address, defaults to any node")
+	flags.StringVarP(&callData, "data", "d", "", "request body as json, or @file")
+	flags.StringArrayVarP(&callFields, "field", "f", nil, "request field as name=value, may be repeated")
+	flags.StringVar(&callURL, "url", "", "stream or stop url returned by start")
+	RootCmd.AddCommand(callCmd)
+}
@@ -6,10 +6,12 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/micro/go-micro/v2"
+	mregistry "github.com/micro/go-micro/v2/registry"
 	"github.com/micro/go-micro/v2/server"
 	"github.com/spf13/cobra"
 
 	"keyayun.com/seal-micro-runner/pkg/logger"
+	"keyayun.com/seal-micro-runner/pkg/redis"
 	"keyayun.com/seal-micro-runner/pkg/services"
 	"keyayun.com/seal-micro-runner/registry"
 
@@ -17,33 +19,81 @@ import (
 	"keyayun.com/seal-micro-runner/pkg/services/carsrender"
 )
 
+const defaultRegistry = "consul"
+
 var (
 	log = logger.WithNamespace("cars-cmd")
 )
 
-func createService(name string) micro.Service {
+// serviceRegistry 按--registry或配置中的registry创建, 默认为consul
+func serviceRegistry() (mregistry.Registry, error) {
+	name := conf.GetString("registry")
+	if name == "" {
+		name = defaultRegistry
+	}
+	reg, err := registry.NewReg(name)
+	if err != nil {
+		log.Errorf("serviceRegistry failed: %v", err)
+		return nil, err
+	}
+	return reg.GetReg(), nil
+}
+
+// createService opts追加在默认选项之后, 不调用serv.Init, 以免go-micro解析os.Args和MICRO_*环境变量覆盖registry
+func createService(name string, opts ...micro.Option) (micro.Service, error) {
 	serviceName := fmt.Sprintf("%s.%s", conf.GetString("task.prefix"), name)
-	reg := registry.NewReg("consul")
-	return micro.NewService(
-		micro.RegisterTTL(time.Second*30),
-		micro.RegisterInterval(time.Second*30),
+	reg, err := serviceRegistry()
+	if err != nil {
+		return nil, err
+	}
+	// interval小于TTL, 节点在重新注册前不会过期
+	opts = append([]micro.Option{
+		micro.RegisterTTL(time.Second * 30),
+		micro.RegisterInterval(time.Second * 15),
 		micro.Name(serviceName),
-		micro.Registry(reg.GetReg()),
-	)
+		micro.Registry(reg),
+	}, opts...)
+	return micro.NewService(opts...), nil
+}
+
+// deregister 在server.Stop之前从registry注销当前节点, 使网关不再路由新的请求
+func deregister(serv micro.Service) error {
+	opts := serv.Server().Options()
+	return opts.Registry.Deregister(&mregistry.Service{
+		Name:    opts.Name,
+		Version: opts.Version,
+		Nodes:   []*mregistry.Node{{Id: opts.Name + "-" + opts.Id}},
+	})
 }
 
 func carsRenderServiceStartUp() error {
-	serv := createService(services.CarsRender)
+	err := redis.Ping()
+	if err != nil {
+		log.Errorf("carsRenderService redis is not reachable: %v", err)
+		return err
+	}
+	sHandler := carsrender.NewCarsRenderService()
+	var serv micro.Service
+	serv, err = createService(services.CarsRender, micro.BeforeStop(func() error {
+		sHandler.Shutdown(func() error {
+			return deregister(serv)
+		})
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
 	serverID := uuid.New().String()
 	serv.Server().Init(server.Id(serverID))
 	// Register Handlers
-	sHandler := carsrender.NewCarsRenderService()
 	sHandler.InitService(serverID)
-	err := pb.RegisterServicesHandler(serv.Server(), sHandler)
+	err = pb.RegisterServicesHandler(serv.Server(), sHandler)
 	if err != nil {
 		log.Error(err)
 		return err
 	}
+	// drain期间注册检查失败, server的定期注册不会把节点重新注册回registry
+	serv.Server().Init(server.RegisterCheck(sHandler.RegisterCheck))
 	// Run server
 	if err := serv.Run(); err != nil {
 		log.Error(err)
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"keyayun.com/seal-micro-runner/pkg/config"
+	"keyayun.com/seal-micro-runner/pkg/redis"
+	"keyayun.com/seal-micro-runner/pkg/sealclient"
+	"keyayun.com/seal-micro-runner/pkg/services/carsrender"
+)
+
+const probeTimeout = time.Second * 3
+
+// doctor 逐项检查运行环境, 一项失败不影响其它检查
+type doctor struct {
+	failed int
+}
+
+func (d *doctor) report(name string, err error, detail string) {
+	if err != nil {
+		d.failed++
+		fmt.Printf("[FAIL] %-10s %v\n", name, err)
+		return
+	}
+	fmt.Printf("[ OK ] %-10s %s\n", name, detail)
+}
+
+func (d *doctor) checkConfig() {
+	d.report("config", config.Err, conf.ConfigFileUsed())
+}
+
+func (d *doctor) checkRedis() error {
+	err := redis.Ping()
+	d.report("redis", err, conf.GetString("redis.addr"))
+	return err
+}
+
+func (d *doctor) checkRegistry() {
+	reg, err := serviceRegistry()
+	if err != nil {
+		d.report("registry", err, "")
+		return
+	}
+	list, err := reg.ListServices()
+	d.report("registry", err, fmt.Sprintf("%s answers, %d services", reg.String(), len(list)))
+}
+
+// checkPort 端口可以监听或已有backend在监听时通过
+func (d *doctor) checkPort(host string, port int) {
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	name := "port " + strconv.Itoa(port)
+	l, err := net.Listen("tcp", addr)
+	if err == nil {
+		l.Close()
+		d.report(name, nil, addr+" free")
+		return
+	}
+	conn, dialErr := net.DialTimeout("tcp", addr, probeTimeout)
+	if dialErr == nil {
+		conn.Close()
+		d.report(name, nil, addr+" listening")
+		return
+	}
+	d.report(name, fmt.Errorf("%s is neither free (%v) nor listening (%v)", addr, err, dialErr), "")
+}
+
+func (d *doctor) checkRootPath() {
+	root := carsrender.RootPath()
+	err := os.MkdirAll(root, 0755)
+	if err == nil {
+		var f *os.File
+		f, err = ioutil.TempFile(root, ".doctor")
+		if err == nil {
+			f.Close()
+			err = os.Remove(f.Name())
+		}
+	}
+	d.report("rootPath", err, root+" writable")
+}
+
+func (d *doctor) checkDomains() {
+	tokens, err := redis.GetRunnerInstances()
+	if err != nil {
+		d.report("domains", err, "")
+		return
+	}
+	seen := make(map[string]bool)
+	var domains []string
+	for _, token := range tokens {
+		if !seen[token.Domain] {
+			seen[token.Domain] = true
+			domains = append(domains, token.Domain)
+		}
+	}
+	sort.Strings(domains)
+	if len(domains) == 0 {
+		d.report("domains", nil, "no registered domain")
+		return
+	}
+	for _, domain := range domains {
+		d.report("domain", sealclient.CheckServerStatus(domain), domain)
+	}
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "check config, redis, registry, render ports, rootPath and registered domains",
+	// 配置读取失败也要继续检查其它项
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		d := &doctor{}
+		d.checkConfig()
+		redisErr := d.checkRedis()
+		d.checkRegistry()
+		host := conf.GetString("host")
+		for _, port := range conf.GetIntSlice("task.cars.render.ports") {
+			d.checkPort(host, port)
+		}
+		d.checkRootPath()
+		if redisErr == nil {
+			d.checkDomains()
+		} else {
+			d.report("domains", fmt.Errorf("skipped, redis is not reachable"), "")
+		}
+		if d.failed > 0 {
+			return fmt.Errorf("doctor: %d checks failed", d.failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(doctorCmd)
+}
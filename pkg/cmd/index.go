@@ -22,6 +22,9 @@ var RootCmd = &cobra.Command{
 	Long: `A Fast and Flexible Static Site Generator built with
 				  love by spf13 and friends in Go.
 				  Complete documentation is available at http://hugo.spf13.com`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return config.Err
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return cmd.Usage()
 	},
@@ -35,6 +38,9 @@ func init() {
 		conf.GetBool("log.os_out"),
 		conf.GetStringMap("log.formatter"),
 		conf.GetStringMap("log.output")))
+	flags := RootCmd.PersistentFlags()
+	flags.String("registry", "", "service registry: consul, etcd, file or memory, defaults to the configured registry")
+	conf.BindPFlag("registry", flags.Lookup("registry"))
 	usageFunc := RootCmd.UsageFunc()
 	RootCmd.SetUsageFunc(func(cmd *cobra.Command) error {
 		usageFunc(cmd)
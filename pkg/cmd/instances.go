@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	pb "keyayun.com/seal-micro-runner/pkg/proto"
+	"keyayun.com/seal-micro-runner/pkg/redis"
+	"keyayun.com/seal-micro-runner/pkg/sealclient"
+)
+
+var (
+	instancesOutput      string
+	instancesShowSecrets bool
+)
+
+// maskSecret 只保留首尾各4个字符
+func maskSecret(s string) string {
+	if instancesShowSecrets || s == "" {
+		return s
+	}
+	if len(s) <= 8 {
+		return "****"
+	}
+	return s[:4] + "****" + s[len(s)-4:]
+}
+
+// maskedToken 返回屏蔽了client secret和token的副本
+func maskedToken(t *pb.TokenModel) *pb.TokenModel {
+	return &pb.TokenModel{
+		ClientId:        t.ClientId,
+		ClientSecret:    maskSecret(t.ClientSecret),
+		ClientName:      t.ClientName,
+		SoftwareId:      t.SoftwareId,
+		SoftwareVersion: t.SoftwareVersion,
+		RefreshToken:    maskSecret(t.RefreshToken),
+		AccessToken:     maskSecret(t.AccessToken),
+		TokenType:       t.TokenType,
+		RefreshUri:      t.RefreshUri,
+		Domain:          t.Domain,
+		Scheme:          t.Scheme,
+	}
+}
+
+type instanceEntry struct {
+	Key   string         `json:"key"`
+	Token *pb.TokenModel `json:"token"`
+}
+
+func sortedInstances(tokens map[string]*pb.TokenModel) []*instanceEntry {
+	entries := make([]*instanceEntry, 0, len(tokens))
+	for key, token := range tokens {
+		entries = append(entries, &instanceEntry{Key: key, Token: maskedToken(token)})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+	return entries
+}
+
+func printJSON(v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+func printInstances(entries []*instanceEntry) error {
+	switch instancesOutput {
+	case "json":
+		return printJSON(entries)
+	case "table":
+	default:
+		return fmt.Errorf("unknown output format %q", instancesOutput)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tDOMAIN\tSCHEME\tCLIENT\tSOFTWARE\tACCESS TOKEN")
+	for _, e := range entries {
+		t := e.Token
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s %s\t%s\n", e.Key, t.Domain, t.Scheme, t.ClientName, t.SoftwareId, t.SoftwareVersion, t.AccessToken)
+	}
+	return w.Flush()
+}
+
+func printInstance(e *instanceEntry) error {
+	switch instancesOutput {
+	case "json":
+		return printJSON(e)
+	case "table":
+	default:
+		return fmt.Errorf("unknown output format %q", instancesOutput)
+	}
+	t := e.Token
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, row := range [][2]string{
+		{"key", e.Key},
+		{"domain", t.Domain},
+		{"scheme", t.Scheme},
+		{"client id", t.ClientId},
+		{"client name", t.ClientName},
+		{"client secret", t.ClientSecret},
+		{"software", t.SoftwareId + " " + t.SoftwareVersion},
+		{"token type", t.TokenType},
+		{"access token", t.AccessToken},
+		{"refresh token", t.RefreshToken},
+		{"refresh uri", t.RefreshUri},
+	} {
+		fmt.Fprintf(w, "%s:\t%s\n", row[0], row[1])
+	}
+	return w.Flush()
+}
+
+var instancesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list registered instances",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tokens, err := redis.GetRunnerInstances()
+		if err != nil {
+			return err
+		}
+		return printInstances(sortedInstances(tokens))
+	},
+}
+
+var instancesShowCmd = &cobra.Command{
+	Use:   "show <key>",
+	Short: "show a registered instance, key is <service>_<domain> as printed by list",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return cmd.Usage()
+		}
+		token, err := redis.GetInstance(args[0])
+		if err != nil {
+			return err
+		}
+		return printInstance(&instanceEntry{Key: args[0], Token: maskedToken(token)})
+	},
+}
+
+var instancesRemoveCmd = &cobra.Command{
+	Use:   "remove <key>",
+	Short: "remove a registered instance",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return cmd.Usage()
+		}
+		token, err := redis.GetInstance(args[0])
+		if err != nil {
+			return err
+		}
+		err = redis.UnregisterInstance(args[0], token)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("removed %s (domain %s)\n", args[0], token.Domain)
+		return nil
+	},
+}
+
+var instancesRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "refresh the access tokens of all registered instances",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		before, err := redis.GetRunnerInstances()
+		if err != nil {
+			return err
+		}
+		err = sealclient.RefreshToken()
+		if err != nil {
+			return err
+		}
+		after, err := redis.GetRunnerInstances()
+		if err != nil {
+			return err
+		}
+		// RefreshToken只记录失败的domain, 通过比较前后的token得到每个instance的结果
+		failed := 0
+		for _, e := range sortedInstances(before) {
+			token := after[e.Key]
+			if token != nil && token.AccessToken != before[e.Key].AccessToken {
+				fmt.Printf("%s\trefreshed\n", e.Key)
+				continue
+			}
+			failed++
+			fmt.Printf("%s\tnot refreshed\n", e.Key)
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d instances were not refreshed", failed, len(before))
+		}
+		return nil
+	},
+}
+
+var instancesCmd = &cobra.Command{
+	Use:   "instances",
+	Short: "inspect the instances registered in redis",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Usage()
+	},
+}
+
+func init() {
+	flags := instancesCmd.PersistentFlags()
+	flags.StringVarP(&instancesOutput, "output", "o", "table", "output format: table or json")
+	flags.BoolVar(&instancesShowSecrets, "show-secrets", false, "print client secrets and tokens unmasked")
+	instancesCmd.AddCommand(instancesListCmd, instancesShowCmd, instancesRemoveCmd, instancesRefreshCmd)
+	RootCmd.AddCommand(instancesCmd)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"keyayun.com/seal-micro-runner/pkg/services/carsrender"
+)
+
+var (
+	replayHost  string
+	replayPort  int
+	replaySid   string
+	replaySpeed float64
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <recording>",
+	Short: "replay a recorded render session against a render backend port",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 || replayPort == 0 {
+			return cmd.Usage()
+		}
+		host := replayHost
+		if host == "" {
+			host = conf.GetString("host")
+		}
+		url := fmt.Sprintf("ws://%s:%d/ws", host, replayPort)
+		return carsrender.Replay(args[0], replaySid, url, replaySpeed)
+	},
+}
+
+func init() {
+	flags := replayCmd.Flags()
+	flags.StringVar(&replayHost, "host", "", "render backend host, defaults to the configured host")
+	flags.IntVar(&replayPort, "port", 0, "render backend port")
+	flags.StringVar(&replaySid, "sid", "", "stream to replay, defaults to the first stream in the recording")
+	flags.Float64Var(&replaySpeed, "speed", 1, "playback speed, 1 keeps the recorded timing and 0 sends without waiting")
+	RootCmd.AddCommand(replayCmd)
+}
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/metadata"
+	mregistry "github.com/micro/go-micro/v2/registry"
+	"github.com/spf13/cobra"
+
+	pb "keyayun.com/seal-micro-runner/pkg/proto"
+	"keyayun.com/seal-micro-runner/pkg/services"
+	"keyayun.com/seal-micro-runner/pkg/services/carsrender"
+)
+
+const rpcTimeout = time.Second * 10
+
+var (
+	sessionsNode   string
+	sessionsOutput string
+	sessionsReason string
+)
+
+func renderServiceName() string {
+	return fmt.Sprintf("%s.%s", conf.GetString("task.prefix"), services.CarsRender)
+}
+
+// nodeMatches node可以用完整id, serverID或地址指定
+func nodeMatches(node *mregistry.Node, name, filter string) bool {
+	return filter == "" || node.Id == filter || node.Id == name+"-"+filter || node.Address == filter
+}
+
+// serviceNodes 从registry中查找服务的节点, filter为空时返回全部
+func serviceNodes(reg mregistry.Registry, name, filter string) ([]*mregistry.Node, error) {
+	list, err := reg.GetService(name)
+	if err != nil {
+		return nil, fmt.Errorf("lookup %s in %s registry failed: %v", name, reg.String(), err)
+	}
+	var nodes []*mregistry.Node
+	for _, svc := range list {
+		for _, node := range svc.Nodes {
+			if nodeMatches(node, name, filter) {
+				nodes = append(nodes, node)
+			}
+		}
+	}
+	if len(nodes) == 0 {
+		if filter != "" {
+			return nil, fmt.Errorf("node %s of %s not found", filter, name)
+		}
+		return nil, fmt.Errorf("no node of %s is registered", name)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Id < nodes[j].Id
+	})
+	return nodes, nil
+}
+
+// servicesClient 通过配置的registry访问名为name的服务
+func servicesClient(name string) (pb.ServicesService, mregistry.Registry, error) {
+	reg, err := serviceRegistry()
+	if err != nil {
+		return nil, nil, err
+	}
+	c := client.NewClient(client.Registry(reg))
+	return pb.NewServicesService(name, c), reg, nil
+}
+
+// adminContext 运维RPC需要携带task.cars.render.admin.token
+func adminContext() (context.Context, context.CancelFunc, error) {
+	token := carsrender.AdminToken()
+	if token == "" {
+		return nil, nil, fmt.Errorf("task.cars.render.admin.token is not set")
+	}
+	ctx := metadata.NewContext(context.Background(), metadata.Metadata{carsrender.AdminTokenHeader: token})
+	ctx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	return ctx, cancel, nil
+}
+
+// nodeName 去掉服务名前缀后的node id, 即render服务的serverID
+func nodeName(node *mregistry.Node) string {
+	return strings.TrimPrefix(node.Id, renderServiceName()+"-")
+}
+
+type nodeSession struct {
+	Node    string          `json:"node"`
+	Address string          `json:"address"`
+	Session *pb.SessionInfo `json:"session"`
+}
+
+func joinPorts(ports []int32) string {
+	list := make([]string, 0, len(ports))
+	for _, port := range ports {
+		list = append(list, fmt.Sprint(port))
+	}
+	return strings.Join(list, ",")
+}
+
+// joinRTT 每个viewport的backend平均往返延迟, 没有样本时为-
+func joinRTT(viewports []*pb.ViewportStats) string {
+	list := make([]string, 0, len(viewports))
+	for _, v := range viewports {
+		if v.BackendRttSamples == 0 {
+			list = append(list, "-")
+			continue
+		}
+		list = append(list, fmt.Sprint(v.BackendRttAvgMs))
+	}
+	return strings.Join(list, ",")
+}
+
+func printSessions(list []*nodeSession) error {
+	switch sessionsOutput {
+	case "json":
+		return printJSON(list)
+	case "table":
+	default:
+		return fmt.Errorf("unknown output format %q", sessionsOutput)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tSESSION\tWORKITEM\tDOMAIN\tSTATE\tPORTS\tSTREAMS\tRTT(MS)\tAGE")
+	for _, ns := range list {
+		s := ns.Session
+		age := time.Since(time.Unix(s.CreatedAt, 0)).Round(time.Second)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%v\n", ns.Node, s.Id, s.WorkItemID, s.Domain, s.State, joinPorts(s.Ports), s.Streams, joinRTT(s.Viewports), age)
+	}
+	return w.Flush()
+}
+
+var sessionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list render sessions of one or all nodes",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		srv, reg, err := servicesClient(renderServiceName())
+		if err != nil {
+			return err
+		}
+		nodes, err := serviceNodes(reg, renderServiceName(), sessionsNode)
+		if err != nil {
+			return err
+		}
+		var list []*nodeSession
+		failed := 0
+		for _, node := range nodes {
+			ctx, cancel, err := adminContext()
+			if err != nil {
+				return err
+			}
+			rsp, err := srv.ListSessions(ctx, &pb.ListSessionsRequest{}, client.WithAddress(node.Address))
+			cancel()
+			if err != nil {
+				failed++
+				fmt.Fprintf(os.Stderr, "node %s (%s): %v\n", nodeName(node), node.Address, err)
+				continue
+			}
+			for _, s := range rsp.Sessions {
+				list = append(list, &nodeSession{Node: nodeName(node), Address: node.Address, Session: s})
+			}
+		}
+		err = printSessions(list)
+		if err != nil {
+			return err
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d nodes did not answer", failed, len(nodes))
+		}
+		return nil
+	},
+}
+
+var sessionsStopCmd = &cobra.Command{
+	Use:   "stop <session id or stream sid>",
+	Short: "stop a render session on the chosen node, or on whichever node holds it",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return cmd.Usage()
+		}
+		srv, reg, err := servicesClient(renderServiceName())
+		if err != nil {
+			return err
+		}
+		nodes, err := serviceNodes(reg, renderServiceName(), sessionsNode)
+		if err != nil {
+			return err
+		}
+		req := &pb.StopSessionRequest{Id: args[0], Reason: sessionsReason}
+		var errs []string
+		for _, node := range nodes {
+			ctx, cancel, err := adminContext()
+			if err != nil {
+				return err
+			}
+			_, err = srv.StopSession(ctx, req, client.WithAddress(node.Address))
+			cancel()
+			if err == nil {
+				fmt.Printf("stopped %s on node %s (%s)\n", args[0], nodeName(node), node.Address)
+				return nil
+			}
+			if merr := merrors.Parse(err.Error()); merr.Code != http.StatusNotFound {
+				errs = append(errs, fmt.Sprintf("node %s: %v", nodeName(node), err))
+			}
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("stop %s failed: %s", args[0], strings.Join(errs, "; "))
+		}
+		return fmt.Errorf("session %s not found on %d nodes", args[0], len(nodes))
+	},
+}
+
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "inspect and stop render sessions of running render services",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Usage()
+	},
+}
+
+func init() {
+	flags := sessionsCmd.PersistentFlags()
+	flags.StringVar(&sessionsNode, "node", "", "node id, server id or address, defaults to all nodes")
+	sessionsListCmd.Flags().StringVarP(&sessionsOutput, "output", "o", "table", "output format: table or json")
+	sessionsStopCmd.Flags().StringVar(&sessionsReason, "reason", "", "reason recorded on the session and its workitem")
+	sessionsCmd.AddCommand(sessionsListCmd, sessionsStopCmd)
+	RootCmd.AddCommand(sessionsCmd)
+}
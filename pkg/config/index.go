@@ -8,6 +8,9 @@ import (
 	"github.com/spf13/viper"
 )
 
+// Err is the error of reading the config file, commands check it before running
+var Err error
+
 // Config is a singleton config manager
 var Config = func() *viper.Viper {
 	config := viper.New()
@@ -24,7 +27,7 @@ var Config = func() *viper.Viper {
 		}
 	}
 	if err != nil {
-		panic(fmt.Errorf("Fatal error config file: %s", err))
+		Err = fmt.Errorf("Fatal error config file: %s", err)
 	}
 	config.AutomaticEnv()
 	replacer := strings.NewReplacer(".", "_")
@@ -465,6 +465,8 @@ type StartRequest struct {
 	BaseWSlink string `protobuf:"bytes,1,opt,name=baseWSlink,proto3" json:"baseWSlink,omitempty"`
 	WorkItemID string `protobuf:"bytes,2,opt,name=workItemID,proto3" json:"workItemID,omitempty"`
 	Domain     string `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
+	Viewports  int32  `protobuf:"varint,4,opt,name=viewports,proto3" json:"viewports,omitempty"`
+	Layout     string `protobuf:"bytes,5,opt,name=layout,proto3" json:"layout,omitempty"`
 }
 
 func (x *StartRequest) Reset() {
@@ -520,13 +522,28 @@ func (x *StartRequest) GetDomain() string {
 	return ""
 }
 
+func (x *StartRequest) GetViewports() int32 {
+	if x != nil {
+		return x.Viewports
+	}
+	return 0
+}
+
+func (x *StartRequest) GetLayout() string {
+	if x != nil {
+		return x.Layout
+	}
+	return ""
+}
+
 type StartResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	StreamUrls []string `protobuf:"bytes,1,rep,name=streamUrls,proto3" json:"streamUrls,omitempty"`
-	StopUrls   []string `protobuf:"bytes,2,rep,name=stopUrls,proto3" json:"stopUrls,omitempty"`
+	StreamUrls  []string `protobuf:"bytes,1,rep,name=streamUrls,proto3" json:"streamUrls,omitempty"`
+	StopUrls    []string `protobuf:"bytes,2,rep,name=stopUrls,proto3" json:"stopUrls,omitempty"`
+	ObserveUrls []string `protobuf:"bytes,3,rep,name=observeUrls,proto3" json:"observeUrls,omitempty"`
 }
 
 func (x *StartResponse) Reset() {
@@ -575,12 +592,21 @@ func (x *StartResponse) GetStopUrls() []string {
 	return nil
 }
 
+func (x *StartResponse) GetObserveUrls() []string {
+	if x != nil {
+		return x.ObserveUrls
+	}
+	return nil
+}
+
 type StopRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	XSid string `protobuf:"bytes,1,opt,name=_sid,json=Sid,proto3" json:"_sid,omitempty"`
+	XExp int64  `protobuf:"varint,2,opt,name=_exp,json=Exp,proto3" json:"_exp,omitempty"`
+	XSig string `protobuf:"bytes,3,opt,name=_sig,json=Sig,proto3" json:"_sig,omitempty"`
 }
 
 func (x *StopRequest) Reset() {
@@ -622,6 +648,20 @@ func (x *StopRequest) GetXSid() string {
 	return ""
 }
 
+func (x *StopRequest) GetXExp() int64 {
+	if x != nil {
+		return x.XExp
+	}
+	return 0
+}
+
+func (x *StopRequest) GetXSig() string {
+	if x != nil {
+		return x.XSig
+	}
+	return ""
+}
+
 type StopResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -665,8 +705,15 @@ type StreamData struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	XSid string `protobuf:"bytes,1,opt,name=_sid,json=Sid,proto3" json:"_sid,omitempty"`
-	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XSid        string `protobuf:"bytes,1,opt,name=_sid,json=Sid,proto3" json:"_sid,omitempty"`
+	Data        []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	MessageType int32  `protobuf:"varint,3,opt,name=messageType,proto3" json:"messageType,omitempty"`
+	CloseCode   int32  `protobuf:"varint,4,opt,name=closeCode,proto3" json:"closeCode,omitempty"`
+	CloseText   string `protobuf:"bytes,5,opt,name=closeText,proto3" json:"closeText,omitempty"`
+	Role        string `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
+	Heartbeat   bool   `protobuf:"varint,7,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	XExp        int64  `protobuf:"varint,8,opt,name=_exp,json=Exp,proto3" json:"_exp,omitempty"`
+	XSig        string `protobuf:"bytes,9,opt,name=_sig,json=Sig,proto3" json:"_sig,omitempty"`
 }
 
 func (x *StreamData) Reset() {
@@ -715,6 +762,591 @@ func (x *StreamData) GetData() []byte {
 	return nil
 }
 
+func (x *StreamData) GetMessageType() int32 {
+	if x != nil {
+		return x.MessageType
+	}
+	return 0
+}
+
+func (x *StreamData) GetCloseCode() int32 {
+	if x != nil {
+		return x.CloseCode
+	}
+	return 0
+}
+
+func (x *StreamData) GetCloseText() string {
+	if x != nil {
+		return x.CloseText
+	}
+	return ""
+}
+
+func (x *StreamData) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *StreamData) GetHeartbeat() bool {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return false
+}
+
+func (x *StreamData) GetXExp() int64 {
+	if x != nil {
+		return x.XExp
+	}
+	return 0
+}
+
+func (x *StreamData) GetXSig() string {
+	if x != nil {
+		return x.XSig
+	}
+	return ""
+}
+
+type SessionInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WorkItemID    string           `protobuf:"bytes,2,opt,name=workItemID,proto3" json:"workItemID,omitempty"`
+	Domain        string           `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
+	Ports         []int32          `protobuf:"varint,4,rep,packed,name=ports,proto3" json:"ports,omitempty"`
+	State         string           `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	CreatedAt     int64            `protobuf:"varint,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	LastActivity  int64            `protobuf:"varint,7,opt,name=lastActivity,proto3" json:"lastActivity,omitempty"`
+	Streams       int32            `protobuf:"varint,8,opt,name=streams,proto3" json:"streams,omitempty"`
+	Sids          []string         `protobuf:"bytes,9,rep,name=sids,proto3" json:"sids,omitempty"`
+	ClientQueue   int32            `protobuf:"varint,10,opt,name=clientQueue,proto3" json:"clientQueue,omitempty"`
+	BackendQueue  int32            `protobuf:"varint,11,opt,name=backendQueue,proto3" json:"backendQueue,omitempty"`
+	DroppedFrames int64            `protobuf:"varint,12,opt,name=droppedFrames,proto3" json:"droppedFrames,omitempty"`
+	Observers     int32            `protobuf:"varint,13,opt,name=observers,proto3" json:"observers,omitempty"`
+	Layout        string           `protobuf:"bytes,14,opt,name=layout,proto3" json:"layout,omitempty"`
+	Transactions  []string         `protobuf:"bytes,15,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Stats         *SessionStats    `protobuf:"bytes,16,opt,name=stats,proto3" json:"stats,omitempty"`
+	Viewports     []*ViewportStats `protobuf:"bytes,17,rep,name=viewports,proto3" json:"viewports,omitempty"`
+}
+
+func (x *SessionInfo) Reset() {
+	*x = SessionInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionInfo) ProtoMessage() {}
+
+func (x *SessionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionInfo.ProtoReflect.Descriptor instead.
+func (*SessionInfo) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SessionInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetWorkItemID() string {
+	if x != nil {
+		return x.WorkItemID
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetPorts() []int32 {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+func (x *SessionInfo) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetLastActivity() int64 {
+	if x != nil {
+		return x.LastActivity
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetStreams() int32 {
+	if x != nil {
+		return x.Streams
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetSids() []string {
+	if x != nil {
+		return x.Sids
+	}
+	return nil
+}
+
+func (x *SessionInfo) GetClientQueue() int32 {
+	if x != nil {
+		return x.ClientQueue
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetBackendQueue() int32 {
+	if x != nil {
+		return x.BackendQueue
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetDroppedFrames() int64 {
+	if x != nil {
+		return x.DroppedFrames
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetObservers() int32 {
+	if x != nil {
+		return x.Observers
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetLayout() string {
+	if x != nil {
+		return x.Layout
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetTransactions() []string {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *SessionInfo) GetStats() *SessionStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *SessionInfo) GetViewports() []*ViewportStats {
+	if x != nil {
+		return x.Viewports
+	}
+	return nil
+}
+
+type SessionStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FramesToClient   int64 `protobuf:"varint,1,opt,name=framesToClient,proto3" json:"framesToClient,omitempty"`
+	BytesToClient    int64 `protobuf:"varint,2,opt,name=bytesToClient,proto3" json:"bytesToClient,omitempty"`
+	FramesToBackend  int64 `protobuf:"varint,3,opt,name=framesToBackend,proto3" json:"framesToBackend,omitempty"`
+	BytesToBackend   int64 `protobuf:"varint,4,opt,name=bytesToBackend,proto3" json:"bytesToBackend,omitempty"`
+	BackendConnectMs int64 `protobuf:"varint,5,opt,name=backendConnectMs,proto3" json:"backendConnectMs,omitempty"`
+	FirstFrameMs     int64 `protobuf:"varint,6,opt,name=firstFrameMs,proto3" json:"firstFrameMs,omitempty"`
+}
+
+func (x *SessionStats) Reset() {
+	*x = SessionStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_service_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionStats) ProtoMessage() {}
+
+func (x *SessionStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_service_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionStats.ProtoReflect.Descriptor instead.
+func (*SessionStats) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SessionStats) GetFramesToClient() int64 {
+	if x != nil {
+		return x.FramesToClient
+	}
+	return 0
+}
+
+func (x *SessionStats) GetBytesToClient() int64 {
+	if x != nil {
+		return x.BytesToClient
+	}
+	return 0
+}
+
+func (x *SessionStats) GetFramesToBackend() int64 {
+	if x != nil {
+		return x.FramesToBackend
+	}
+	return 0
+}
+
+func (x *SessionStats) GetBytesToBackend() int64 {
+	if x != nil {
+		return x.BytesToBackend
+	}
+	return 0
+}
+
+func (x *SessionStats) GetBackendConnectMs() int64 {
+	if x != nil {
+		return x.BackendConnectMs
+	}
+	return 0
+}
+
+func (x *SessionStats) GetFirstFrameMs() int64 {
+	if x != nil {
+		return x.FirstFrameMs
+	}
+	return 0
+}
+
+type ViewportStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sid               string `protobuf:"bytes,1,opt,name=sid,proto3" json:"sid,omitempty"`
+	Port              int32  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	BackendRttAvgMs   int64  `protobuf:"varint,3,opt,name=backendRttAvgMs,proto3" json:"backendRttAvgMs,omitempty"`
+	BackendRttMaxMs   int64  `protobuf:"varint,4,opt,name=backendRttMaxMs,proto3" json:"backendRttMaxMs,omitempty"`
+	BackendRttLastMs  int64  `protobuf:"varint,5,opt,name=backendRttLastMs,proto3" json:"backendRttLastMs,omitempty"`
+	BackendRttSamples int64  `protobuf:"varint,6,opt,name=backendRttSamples,proto3" json:"backendRttSamples,omitempty"`
+}
+
+func (x *ViewportStats) Reset() {
+	*x = ViewportStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_service_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ViewportStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewportStats) ProtoMessage() {}
+
+func (x *ViewportStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_service_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewportStats.ProtoReflect.Descriptor instead.
+func (*ViewportStats) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ViewportStats) GetSid() string {
+	if x != nil {
+		return x.Sid
+	}
+	return ""
+}
+
+func (x *ViewportStats) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ViewportStats) GetBackendRttAvgMs() int64 {
+	if x != nil {
+		return x.BackendRttAvgMs
+	}
+	return 0
+}
+
+func (x *ViewportStats) GetBackendRttMaxMs() int64 {
+	if x != nil {
+		return x.BackendRttMaxMs
+	}
+	return 0
+}
+
+func (x *ViewportStats) GetBackendRttLastMs() int64 {
+	if x != nil {
+		return x.BackendRttLastMs
+	}
+	return 0
+}
+
+func (x *ViewportStats) GetBackendRttSamples() int64 {
+	if x != nil {
+		return x.BackendRttSamples
+	}
+	return 0
+}
+
+type ListSessionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_service_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_service_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_service_proto_rawDescGZIP(), []int{14}
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sessions []*SessionInfo `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*SessionInfo {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type GetSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetSessionRequest) Reset() {
+	*x = GetSessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSessionRequest) ProtoMessage() {}
+
+func (x *GetSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetSessionRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetSessionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type StopSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *StopSessionRequest) Reset() {
+	*x = StopSessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_service_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopSessionRequest) ProtoMessage() {}
+
+func (x *StopSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopSessionRequest.ProtoReflect.Descriptor instead.
+func (*StopSessionRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StopSessionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StopSessionRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 var File_pkg_proto_service_proto protoreflect.FileDescriptor
 
 var file_pkg_proto_service_proto_rawDesc = []byte{
@@ -777,70 +1409,191 @@ var file_pkg_proto_service_proto_rawDesc = []byte{
 	0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d,
 	0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x22,
 	0x0f, 0x0a, 0x0d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x66, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x61, 0x73, 0x65, 0x57, 0x53, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x61, 0x73, 0x65, 0x57, 0x53, 0x6c, 0x69, 0x6e, 0x6b,
-	0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x74, 0x65, 0x6d, 0x49, 0x44, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x74, 0x65, 0x6d, 0x49, 0x44,
-	0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x22, 0x4b, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x72,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x55, 0x72, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x73,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x72, 0x6c, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x6f,
-	0x70, 0x55, 0x72, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x6f,
-	0x70, 0x55, 0x72, 0x6c, 0x73, 0x22, 0x20, 0x0a, 0x0b, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x11, 0x0a, 0x04, 0x5f, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x53, 0x69, 0x64, 0x22, 0x0e, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x70, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x33, 0x0a, 0x0a, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x22, 0x9c, 0x01, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x61, 0x73, 0x65, 0x57, 0x53, 0x6c, 0x69, 0x6e, 0x6b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x61, 0x73, 0x65, 0x57, 0x53, 0x6c, 0x69, 0x6e,
+	0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x74, 0x65, 0x6d, 0x49, 0x44, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x74, 0x65, 0x6d, 0x49,
+	0x44, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x69, 0x65,
+	0x77, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x76, 0x69,
+	0x65, 0x77, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x79, 0x6f, 0x75,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x22,
+	0x6d, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x72, 0x6c, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x72, 0x6c, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x6f, 0x70, 0x55, 0x72, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x6f, 0x70, 0x55, 0x72, 0x6c, 0x73, 0x12, 0x20, 0x0a, 0x0b,
+	0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x55, 0x72, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0b, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x55, 0x72, 0x6c, 0x73, 0x22, 0x46,
+	0x0a, 0x0b, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x11, 0x0a,
+	0x04, 0x5f, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x53, 0x69, 0x64,
+	0x12, 0x11, 0x0a, 0x04, 0x5f, 0x65, 0x78, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03,
+	0x45, 0x78, 0x70, 0x12, 0x11, 0x0a, 0x04, 0x5f, 0x73, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x53, 0x69, 0x67, 0x22, 0x0e, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xe9, 0x01, 0x0a, 0x0a, 0x53, 0x74, 0x72, 0x65, 0x61,
 	0x6d, 0x44, 0x61, 0x74, 0x61, 0x12, 0x11, 0x0a, 0x04, 0x5f, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x03, 0x53, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x32, 0xcb, 0x05, 0x0a,
-	0x08, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x67, 0x0a, 0x08, 0x4d, 0x61, 0x6e,
-	0x69, 0x66, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x63, 0x6c, 0x6f, 0x73, 0x65, 0x54, 0x65, 0x78, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x54, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f,
+	0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x11, 0x0a, 0x04,
+	0x5f, 0x65, 0x78, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x45, 0x78, 0x70, 0x12,
+	0x11, 0x0a, 0x04, 0x5f, 0x73, 0x69, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x53,
+	0x69, 0x67, 0x22, 0xc4, 0x04, 0x0a, 0x0b, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x74, 0x65, 0x6d, 0x49, 0x44,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x74, 0x65, 0x6d,
+	0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f,
+	0x72, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x05, 0x52, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x63, 0x74, 0x69,
+	0x76, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74,
+	0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x64, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x04, 0x73, 0x69, 0x64, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x51, 0x75, 0x65, 0x75, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x51, 0x75, 0x65, 0x75, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x62, 0x61, 0x63, 0x6b,
+	0x65, 0x6e, 0x64, 0x51, 0x75, 0x65, 0x75, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c,
+	0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x51, 0x75, 0x65, 0x75, 0x65, 0x12, 0x24, 0x0a, 0x0d,
+	0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0d, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x46, 0x72, 0x61, 0x6d,
+	0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x40, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6b, 0x65,
+	0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
+	0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x49,
+	0x0a, 0x09, 0x76, 0x69, 0x65, 0x77, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2b, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c,
+	0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x2e, 0x56, 0x69, 0x65, 0x77, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x09,
+	0x76, 0x69, 0x65, 0x77, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22, 0xfe, 0x01, 0x0a, 0x0c, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x66, 0x72,
+	0x61, 0x6d, 0x65, 0x73, 0x54, 0x6f, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0e, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x54, 0x6f, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x62, 0x79, 0x74, 0x65, 0x73, 0x54, 0x6f, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x54, 0x6f, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x66, 0x72, 0x61, 0x6d,
+	0x65, 0x73, 0x54, 0x6f, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x54, 0x6f, 0x42, 0x61, 0x63, 0x6b, 0x65,
+	0x6e, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x62, 0x79, 0x74, 0x65, 0x73, 0x54, 0x6f, 0x42, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x62, 0x79, 0x74, 0x65,
+	0x73, 0x54, 0x6f, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x62, 0x61,
+	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x4d, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x4d, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x69, 0x72, 0x73, 0x74, 0x46,
+	0x72, 0x61, 0x6d, 0x65, 0x4d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x66, 0x69,
+	0x72, 0x73, 0x74, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x4d, 0x73, 0x22, 0xe3, 0x01, 0x0a, 0x0d, 0x56,
+	0x69, 0x65, 0x77, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x10, 0x0a, 0x03,
+	0x73, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f,
+	0x72, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x52, 0x74, 0x74,
+	0x41, 0x76, 0x67, 0x4d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x62, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x52, 0x74, 0x74, 0x41, 0x76, 0x67, 0x4d, 0x73, 0x12, 0x28, 0x0a, 0x0f,
+	0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x52, 0x74, 0x74, 0x4d, 0x61, 0x78, 0x4d, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x52, 0x74,
+	0x74, 0x4d, 0x61, 0x78, 0x4d, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e,
+	0x64, 0x52, 0x74, 0x74, 0x4c, 0x61, 0x73, 0x74, 0x4d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x10, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x52, 0x74, 0x74, 0x4c, 0x61, 0x73, 0x74,
+	0x4d, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x52, 0x74, 0x74,
+	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x62,
+	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x52, 0x74, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73,
+	0x22, 0x15, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5d, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x45, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x29, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c,
+	0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x23, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x3c, 0x0a, 0x12, 0x53,
+	0x74, 0x6f, 0x70, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x32, 0x9f, 0x08, 0x0a, 0x08, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x67, 0x0a, 0x08, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65,
+	0x73, 0x74, 0x12, 0x2d, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61,
+	0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x2e, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2a, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c,
+	0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x2e, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00, 0x12,
+	0x63, 0x0a, 0x08, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x28, 0x2e, 0x6b, 0x65,
+	0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
+	0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x1a, 0x2b, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e,
 	0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x73, 0x2e, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73,
-	0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x73, 0x2e, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x66, 0x6f,
-	0x22, 0x00, 0x12, 0x63, 0x0a, 0x08, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x28,
+	0x69, 0x63, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x61, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x28,
 	0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75,
 	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x54, 0x6f,
 	0x6b, 0x65, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x1a, 0x2b, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79,
 	0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73,
 	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x61, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x12, 0x28, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c,
-	0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73,
-	0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x1a, 0x2b, 0x2e, 0x6b, 0x65,
-	0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
-	0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x65, 0x0a, 0x0a, 0x55, 0x6e,
-	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x28, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79,
-	0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4d, 0x6f, 0x64,
-	0x65, 0x6c, 0x1a, 0x2b, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61,
-	0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x73, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x62, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2a, 0x2e, 0x6b, 0x65, 0x79,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x65, 0x0a, 0x0a, 0x55, 0x6e, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x28, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e,
+	0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x1a,
+	0x2b, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72,
+	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x62,
+	0x0a, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2a, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75,
+	0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65,
+	0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x5f, 0x0a, 0x04, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x29, 0x2e, 0x6b, 0x65, 0x79,
 	0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
-	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e,
-	0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5f, 0x0a, 0x04, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x29, 0x2e,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e,
+	0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x62, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x28, 0x2e,
 	0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e,
-	0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x6f,
-	0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79,
-	0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x62, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x12, 0x28, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e,
-	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e,
-	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x28, 0x2e, 0x6b, 0x65, 0x79,
+	0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x28, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75,
+	0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x44, 0x61, 0x74,
+	0x61, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x77, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x31, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75,
+	0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x6b, 0x65, 0x79,
 	0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
-	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x6a, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2f,
+	0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75,
+	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x47, 0x65,
+	0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x29, 0x2e, 0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72,
+	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00, 0x12, 0x6d, 0x0a, 0x0b,
+	0x53, 0x74, 0x6f, 0x70, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x2e, 0x6b, 0x65,
+	0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
+	0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e,
+	0x6b, 0x65, 0x79, 0x61, 0x79, 0x75, 0x6e, 0x2e, 0x73, 0x65, 0x61, 0x6c, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x6f,
+	0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -855,42 +1608,58 @@ func file_pkg_proto_service_proto_rawDescGZIP() []byte {
 	return file_pkg_proto_service_proto_rawDescData
 }
 
-var file_pkg_proto_service_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_pkg_proto_service_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_pkg_proto_service_proto_goTypes = []interface{}{
-	(*ManifestRequest)(nil), // 0: keyayun.seal.runner.services.ManifestRequest
-	(*Param)(nil),           // 1: keyayun.seal.runner.services.Param
-	(*Trigger)(nil),         // 2: keyayun.seal.runner.services.Trigger
-	(*ManifestInfo)(nil),    // 3: keyayun.seal.runner.services.ManifestInfo
-	(*TokenModel)(nil),      // 4: keyayun.seal.runner.services.TokenModel
-	(*TokenResponse)(nil),   // 5: keyayun.seal.runner.services.TokenResponse
-	(*StartRequest)(nil),    // 6: keyayun.seal.runner.services.StartRequest
-	(*StartResponse)(nil),   // 7: keyayun.seal.runner.services.StartResponse
-	(*StopRequest)(nil),     // 8: keyayun.seal.runner.services.StopRequest
-	(*StopResponse)(nil),    // 9: keyayun.seal.runner.services.StopResponse
-	(*StreamData)(nil),      // 10: keyayun.seal.runner.services.StreamData
+	(*ManifestRequest)(nil),      // 0: keyayun.seal.runner.services.ManifestRequest
+	(*Param)(nil),                // 1: keyayun.seal.runner.services.Param
+	(*Trigger)(nil),              // 2: keyayun.seal.runner.services.Trigger
+	(*ManifestInfo)(nil),         // 3: keyayun.seal.runner.services.ManifestInfo
+	(*TokenModel)(nil),           // 4: keyayun.seal.runner.services.TokenModel
+	(*TokenResponse)(nil),        // 5: keyayun.seal.runner.services.TokenResponse
+	(*StartRequest)(nil),         // 6: keyayun.seal.runner.services.StartRequest
+	(*StartResponse)(nil),        // 7: keyayun.seal.runner.services.StartResponse
+	(*StopRequest)(nil),          // 8: keyayun.seal.runner.services.StopRequest
+	(*StopResponse)(nil),         // 9: keyayun.seal.runner.services.StopResponse
+	(*StreamData)(nil),           // 10: keyayun.seal.runner.services.StreamData
+	(*SessionInfo)(nil),          // 11: keyayun.seal.runner.services.SessionInfo
+	(*SessionStats)(nil),         // 12: keyayun.seal.runner.services.SessionStats
+	(*ViewportStats)(nil),        // 13: keyayun.seal.runner.services.ViewportStats
+	(*ListSessionsRequest)(nil),  // 14: keyayun.seal.runner.services.ListSessionsRequest
+	(*ListSessionsResponse)(nil), // 15: keyayun.seal.runner.services.ListSessionsResponse
+	(*GetSessionRequest)(nil),    // 16: keyayun.seal.runner.services.GetSessionRequest
+	(*StopSessionRequest)(nil),   // 17: keyayun.seal.runner.services.StopSessionRequest
 }
 var file_pkg_proto_service_proto_depIdxs = []int32{
 	1,  // 0: keyayun.seal.runner.services.ManifestInfo.params:type_name -> keyayun.seal.runner.services.Param
 	2,  // 1: keyayun.seal.runner.services.ManifestInfo.services:type_name -> keyayun.seal.runner.services.Trigger
-	0,  // 2: keyayun.seal.runner.services.Services.Manifest:input_type -> keyayun.seal.runner.services.ManifestRequest
-	4,  // 3: keyayun.seal.runner.services.Services.Register:input_type -> keyayun.seal.runner.services.TokenModel
-	4,  // 4: keyayun.seal.runner.services.Services.Update:input_type -> keyayun.seal.runner.services.TokenModel
-	4,  // 5: keyayun.seal.runner.services.Services.UnRegister:input_type -> keyayun.seal.runner.services.TokenModel
-	6,  // 6: keyayun.seal.runner.services.Services.Start:input_type -> keyayun.seal.runner.services.StartRequest
-	8,  // 7: keyayun.seal.runner.services.Services.Stop:input_type -> keyayun.seal.runner.services.StopRequest
-	10, // 8: keyayun.seal.runner.services.Services.Stream:input_type -> keyayun.seal.runner.services.StreamData
-	3,  // 9: keyayun.seal.runner.services.Services.Manifest:output_type -> keyayun.seal.runner.services.ManifestInfo
-	5,  // 10: keyayun.seal.runner.services.Services.Register:output_type -> keyayun.seal.runner.services.TokenResponse
-	5,  // 11: keyayun.seal.runner.services.Services.Update:output_type -> keyayun.seal.runner.services.TokenResponse
-	5,  // 12: keyayun.seal.runner.services.Services.UnRegister:output_type -> keyayun.seal.runner.services.TokenResponse
-	7,  // 13: keyayun.seal.runner.services.Services.Start:output_type -> keyayun.seal.runner.services.StartResponse
-	9,  // 14: keyayun.seal.runner.services.Services.Stop:output_type -> keyayun.seal.runner.services.StopResponse
-	10, // 15: keyayun.seal.runner.services.Services.Stream:output_type -> keyayun.seal.runner.services.StreamData
-	9,  // [9:16] is the sub-list for method output_type
-	2,  // [2:9] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	12, // 2: keyayun.seal.runner.services.SessionInfo.stats:type_name -> keyayun.seal.runner.services.SessionStats
+	13, // 3: keyayun.seal.runner.services.SessionInfo.viewports:type_name -> keyayun.seal.runner.services.ViewportStats
+	11, // 4: keyayun.seal.runner.services.ListSessionsResponse.sessions:type_name -> keyayun.seal.runner.services.SessionInfo
+	0,  // 5: keyayun.seal.runner.services.Services.Manifest:input_type -> keyayun.seal.runner.services.ManifestRequest
+	4,  // 6: keyayun.seal.runner.services.Services.Register:input_type -> keyayun.seal.runner.services.TokenModel
+	4,  // 7: keyayun.seal.runner.services.Services.Update:input_type -> keyayun.seal.runner.services.TokenModel
+	4,  // 8: keyayun.seal.runner.services.Services.UnRegister:input_type -> keyayun.seal.runner.services.TokenModel
+	6,  // 9: keyayun.seal.runner.services.Services.Start:input_type -> keyayun.seal.runner.services.StartRequest
+	8,  // 10: keyayun.seal.runner.services.Services.Stop:input_type -> keyayun.seal.runner.services.StopRequest
+	10, // 11: keyayun.seal.runner.services.Services.Stream:input_type -> keyayun.seal.runner.services.StreamData
+	14, // 12: keyayun.seal.runner.services.Services.ListSessions:input_type -> keyayun.seal.runner.services.ListSessionsRequest
+	16, // 13: keyayun.seal.runner.services.Services.GetSession:input_type -> keyayun.seal.runner.services.GetSessionRequest
+	17, // 14: keyayun.seal.runner.services.Services.StopSession:input_type -> keyayun.seal.runner.services.StopSessionRequest
+	3,  // 15: keyayun.seal.runner.services.Services.Manifest:output_type -> keyayun.seal.runner.services.ManifestInfo
+	5,  // 16: keyayun.seal.runner.services.Services.Register:output_type -> keyayun.seal.runner.services.TokenResponse
+	5,  // 17: keyayun.seal.runner.services.Services.Update:output_type -> keyayun.seal.runner.services.TokenResponse
+	5,  // 18: keyayun.seal.runner.services.Services.UnRegister:output_type -> keyayun.seal.runner.services.TokenResponse
+	7,  // 19: keyayun.seal.runner.services.Services.Start:output_type -> keyayun.seal.runner.services.StartResponse
+	9,  // 20: keyayun.seal.runner.services.Services.Stop:output_type -> keyayun.seal.runner.services.StopResponse
+	10, // 21: keyayun.seal.runner.services.Services.Stream:output_type -> keyayun.seal.runner.services.StreamData
+	15, // 22: keyayun.seal.runner.services.Services.ListSessions:output_type -> keyayun.seal.runner.services.ListSessionsResponse
+	11, // 23: keyayun.seal.runner.services.Services.GetSession:output_type -> keyayun.seal.runner.services.SessionInfo
+	9,  // 24: keyayun.seal.runner.services.Services.StopSession:output_type -> keyayun.seal.runner.services.StopResponse
+	15, // [15:25] is the sub-list for method output_type
+	5,  // [5:15] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_pkg_proto_service_proto_init() }
@@ -1031,6 +1800,90 @@ func file_pkg_proto_service_proto_init() {
 				return nil
 			}
 		}
+		file_pkg_proto_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ViewportStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSessionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSessionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1038,7 +1891,7 @@ func file_pkg_proto_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_proto_service_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -49,6 +49,9 @@ type ServicesService interface {
 	Start(ctx context.Context, in *StartRequest, opts ...client.CallOption) (*StartResponse, error)
 	Stop(ctx context.Context, in *StopRequest, opts ...client.CallOption) (*StopResponse, error)
 	Stream(ctx context.Context, opts ...client.CallOption) (Services_StreamService, error)
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...client.CallOption) (*ListSessionsResponse, error)
+	GetSession(ctx context.Context, in *GetSessionRequest, opts ...client.CallOption) (*SessionInfo, error)
+	StopSession(ctx context.Context, in *StopSessionRequest, opts ...client.CallOption) (*StopResponse, error)
 }
 
 type servicesService struct {
@@ -174,6 +177,36 @@ func (x *servicesServiceStream) Recv() (*StreamData, error) {
 	return m, nil
 }
 
+func (c *servicesService) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...client.CallOption) (*ListSessionsResponse, error) {
+	req := c.c.NewRequest(c.name, "Services.ListSessions", in)
+	out := new(ListSessionsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *servicesService) GetSession(ctx context.Context, in *GetSessionRequest, opts ...client.CallOption) (*SessionInfo, error) {
+	req := c.c.NewRequest(c.name, "Services.GetSession", in)
+	out := new(SessionInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *servicesService) StopSession(ctx context.Context, in *StopSessionRequest, opts ...client.CallOption) (*StopResponse, error) {
+	req := c.c.NewRequest(c.name, "Services.StopSession", in)
+	out := new(StopResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Services service
 
 type ServicesHandler interface {
@@ -184,6 +217,9 @@ type ServicesHandler interface {
 	Start(context.Context, *StartRequest, *StartResponse) error
 	Stop(context.Context, *StopRequest, *StopResponse) error
 	Stream(context.Context, Services_StreamStream) error
+	ListSessions(context.Context, *ListSessionsRequest, *ListSessionsResponse) error
+	GetSession(context.Context, *GetSessionRequest, *SessionInfo) error
+	StopSession(context.Context, *StopSessionRequest, *StopResponse) error
 }
 
 func RegisterServicesHandler(s server.Server, hdlr ServicesHandler, opts ...server.HandlerOption) error {
@@ -195,6 +231,9 @@ func RegisterServicesHandler(s server.Server, hdlr ServicesHandler, opts ...serv
 		Start(ctx context.Context, in *StartRequest, out *StartResponse) error
 		Stop(ctx context.Context, in *StopRequest, out *StopResponse) error
 		Stream(ctx context.Context, stream server.Stream) error
+		ListSessions(ctx context.Context, in *ListSessionsRequest, out *ListSessionsResponse) error
+		GetSession(ctx context.Context, in *GetSessionRequest, out *SessionInfo) error
+		StopSession(ctx context.Context, in *StopSessionRequest, out *StopResponse) error
 	}
 	type Services struct {
 		services
@@ -275,3 +314,15 @@ func (x *servicesStreamStream) Recv() (*StreamData, error) {
 	}
 	return m, nil
 }
+
+func (h *servicesHandler) ListSessions(ctx context.Context, in *ListSessionsRequest, out *ListSessionsResponse) error {
+	return h.ServicesHandler.ListSessions(ctx, in, out)
+}
+
+func (h *servicesHandler) GetSession(ctx context.Context, in *GetSessionRequest, out *SessionInfo) error {
+	return h.ServicesHandler.GetSession(ctx, in, out)
+}
+
+func (h *servicesHandler) StopSession(ctx context.Context, in *StopSessionRequest, out *StopResponse) error {
+	return h.ServicesHandler.StopSession(ctx, in, out)
+}
@@ -3,6 +3,7 @@ package redis
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/go-redis/redis"
 
@@ -13,11 +14,22 @@ import (
 
 var tableIndex = struct {
 	Instances int
+	Ports     int
 }{
 	14,
+	15,
 }
 
-const instancesKey = "Instances"
+const (
+	instancesKey = "Instances"
+	portsKey     = "RenderPorts"
+)
+
+// 仅当租约属于owner时续租/释放
+const (
+	renewPortScript   = `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("PEXPIRE", KEYS[1], ARGV[2]) end return 0`
+	releasePortScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) end return 0`
+)
 
 var (
 	client *redis.Client
@@ -37,10 +49,21 @@ func init() {
 
 	_, err := client.Ping().Result()
 	if err != nil {
-		panic(fmt.Errorf("Fatal error redis: %s", err))
+		log.Errorf("Fatal error redis: %s", err)
 	}
 }
 
+// Ping 检查Redis可连接, 并且instances和端口租约所在的库可用
+func Ping() error {
+	pl := client.TxPipeline()
+	pl.Select(tableIndex.Instances)
+	pl.Ping()
+	pl.Select(tableIndex.Ports)
+	pl.Ping()
+	_, err := pl.Exec()
+	return err
+}
+
 // RegisterInstance 注册instance
 func RegisterInstance(serviceName string, s *pb.TokenModel) error {
 	b, err := json.Marshal(s)
@@ -57,11 +80,11 @@ func RegisterInstance(serviceName string, s *pb.TokenModel) error {
 	return err
 }
 
-// UnregisterInstance 反注册instance
+// UnregisterInstance 反注册instance, serviceName与RegisterInstance相同, 已包含domain
 func UnregisterInstance(serviceName string, s *pb.TokenModel) error {
 	pl := client.TxPipeline()
 	pl.Select(tableIndex.Instances)
-	pl.HDel(instancesKey, serviceName+"_"+s.Domain)
+	pl.HDel(instancesKey, serviceName)
 	_, err := pl.Exec()
 	return err
 }
@@ -105,7 +128,7 @@ func GetRunnerInstances() (map[string]*pb.TokenModel, error) {
 		log.Errorf("GetRunnerInstances failed when get redis result: %v", err)
 		return nil, err
 	}
-	var sts map[string]*pb.TokenModel
+	sts := make(map[string]*pb.TokenModel, len(jss))
 	for serviceName, v := range jss {
 		var st pb.TokenModel
 		err = json.Unmarshal([]byte(v), &st)
@@ -117,3 +140,72 @@ func GetRunnerInstances() (map[string]*pb.TokenModel, error) {
 	}
 	return sts, nil
 }
+
+func portKey(host string, port int) string {
+	return fmt.Sprintf("%s:%s:%d", portsKey, host, port)
+}
+
+// LeasePort 租用host上的端口, 端口已被租用时返回false
+func LeasePort(host string, port int, owner string, ttl time.Duration) (bool, error) {
+	pl := client.TxPipeline()
+	pl.Select(tableIndex.Ports)
+	set := pl.SetNX(portKey(host, port), owner, ttl)
+	_, err := pl.Exec()
+	if err != nil {
+		log.Errorf("LeasePort failed when exec redis command: %v", err)
+		return false, err
+	}
+	return set.Result()
+}
+
+// RenewPort 续租端口, 租约已不属于owner时返回false
+func RenewPort(host string, port int, owner string, ttl time.Duration) (bool, error) {
+	pl := client.TxPipeline()
+	pl.Select(tableIndex.Ports)
+	renew := pl.Eval(renewPortScript, []string{portKey(host, port)}, owner, int64(ttl/time.Millisecond))
+	_, err := pl.Exec()
+	if err != nil {
+		log.Errorf("RenewPort failed when exec redis command: %v", err)
+		return false, err
+	}
+	n, err := renew.Int64()
+	if err != nil {
+		log.Errorf("RenewPort failed when get redis result: %v", err)
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// ReleasePort 释放owner持有的端口租约
+func ReleasePort(host string, port int, owner string) error {
+	pl := client.TxPipeline()
+	pl.Select(tableIndex.Ports)
+	pl.Eval(releasePortScript, []string{portKey(host, port)}, owner)
+	_, err := pl.Exec()
+	return err
+}
+
+// CountLeasedPorts host上ports中已被租用的端口数量
+func CountLeasedPorts(host string, ports []int) (int, error) {
+	if len(ports) == 0 {
+		return 0, nil
+	}
+	keys := make([]string, 0, len(ports))
+	for _, port := range ports {
+		keys = append(keys, portKey(host, port))
+	}
+	pl := client.TxPipeline()
+	pl.Select(tableIndex.Ports)
+	exists := pl.Exists(keys...)
+	_, err := pl.Exec()
+	if err != nil {
+		log.Errorf("CountLeasedPorts failed when exec redis command: %v", err)
+		return 0, err
+	}
+	n, err := exists.Result()
+	if err != nil {
+		log.Errorf("CountLeasedPorts failed when get redis result: %v", err)
+		return 0, err
+	}
+	return int(n), nil
+}
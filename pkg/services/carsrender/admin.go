@@ -0,0 +1,30 @@
+package carsrender
+
+import (
+	"context"
+	"crypto/subtle"
+
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/metadata"
+)
+
+// AdminTokenHeader ListSessions, GetSession和StopSession通过此metadata携带运维token
+const AdminTokenHeader = "X-Render-Admin-Token"
+
+// AdminToken 运维token, 未配置时运维RPC全部拒绝
+func AdminToken() string {
+	return conf.GetString("task.cars.render.admin.token")
+}
+
+// checkAdmin 运维RPC与Start/Stream在同一个handler上, 网关可以路由到, 所以必须校验token
+func checkAdmin(ctx context.Context) error {
+	token := AdminToken()
+	if token == "" {
+		return merrors.Forbidden("carsRender.adminDisabled", "admin rpcs are disabled, task.cars.render.admin.token is not set")
+	}
+	got, _ := metadata.Get(ctx, AdminTokenHeader)
+	if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+		return merrors.Forbidden("carsRender.adminForbidden", "admin token is missing or wrong")
+	}
+	return nil
+}
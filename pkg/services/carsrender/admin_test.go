@@ -0,0 +1,43 @@
+package carsrender
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	merrors "github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/metadata"
+)
+
+func adminCode(err error) int32 {
+	if err == nil {
+		return 0
+	}
+	return merrors.Parse(err.Error()).Code
+}
+
+func TestCheckAdmin(t *testing.T) {
+	defer conf.Set("task.cars.render.admin.token", "")
+	withToken := func(token string) context.Context {
+		return metadata.NewContext(context.Background(), metadata.Metadata{AdminTokenHeader: token})
+	}
+
+	conf.Set("task.cars.render.admin.token", "")
+	if code := adminCode(checkAdmin(withToken(""))); code != http.StatusForbidden {
+		t.Fatalf("checkAdmin without a configured token = %d, want 403", code)
+	}
+
+	conf.Set("task.cars.render.admin.token", "secret")
+	if err := checkAdmin(withToken("secret")); err != nil {
+		t.Fatalf("checkAdmin with the right token: %v", err)
+	}
+	for name, ctx := range map[string]context.Context{
+		"no metadata": context.Background(),
+		"wrong token": withToken("secrets"),
+		"empty token": withToken(""),
+	} {
+		if code := adminCode(checkAdmin(ctx)); code != http.StatusForbidden {
+			t.Errorf("%s: checkAdmin = %d, want 403", name, code)
+		}
+	}
+}
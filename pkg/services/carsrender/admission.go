@@ -0,0 +1,153 @@
+package carsrender
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	merrors "github.com/micro/go-micro/v2/errors"
+)
+
+// ErrCapacityExhausted 端口不足时返回的错误id, Detail以"retryAfter=<秒>"结尾
+const ErrCapacityExhausted = "carsRender.capacityExhausted"
+
+const (
+	defaultAdmissionQueue   = 16
+	defaultAdmissionTimeout = 60
+	defaultRetryAfter       = 30
+	admissionRetryInterval  = time.Second
+)
+
+// admission Start在有界的FIFO队列中等待, 只有队首尝试预留端口
+// 预留端口时不持有mu, Redis端口池的网络请求不会阻塞release
+type admission struct {
+	mu       sync.Mutex
+	queue    []*admissionTicket
+	released chan struct{}
+}
+
+type admissionTicket struct {
+	turn chan struct{}
+}
+
+func newAdmission() *admission {
+	return &admission{released: make(chan struct{})}
+}
+
+func admissionQueueSize() int {
+	if n := conf.GetInt("task.cars.render.admission.queueSize"); n > 0 {
+		return n
+	}
+	return defaultAdmissionQueue
+}
+
+// admissionTimeout Start在队列中最长等待的时间
+func admissionTimeout() time.Duration {
+	if t := conf.GetInt("task.cars.render.admission.timeout"); t > 0 {
+		return time.Second * time.Duration(t)
+	}
+	return time.Second * defaultAdmissionTimeout
+}
+
+func retryAfter() time.Duration {
+	if t := conf.GetInt("task.cars.render.admission.retryAfter"); t > 0 {
+		return time.Second * time.Duration(t)
+	}
+	return time.Second * defaultRetryAfter
+}
+
+// capacityExhausted 带retry-after提示的503错误
+func capacityExhausted(reason string) error {
+	free, _ := pl.free()
+	return merrors.New(ErrCapacityExhausted, fmt.Sprintf("capacity exhausted: %s, %d of %d ports free, retryAfter=%d",
+		reason, free, pl.size(), int(retryAfter()/time.Second)), http.StatusServiceUnavailable)
+}
+
+// reservePorts 预留n个端口, 全部成功或全部归还
+func reservePorts(n int, owner string) ([]int, error) {
+	ports := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		port, err := pl.pull(owner)
+		if err != nil {
+			for _, p := range ports {
+				pl.push(p, owner)
+			}
+			return nil, err
+		}
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
+// admit 预留n个端口, 端口不足时排队直到有端口归还或超时
+// 队首之外最多admissionQueueSize个Start等待
+func (a *admission) admit(ctx context.Context, n int, owner string) ([]int, error) {
+	a.mu.Lock()
+	if len(a.queue) > admissionQueueSize() {
+		a.mu.Unlock()
+		return nil, capacityExhausted("admission queue is full")
+	}
+	t := &admissionTicket{turn: make(chan struct{})}
+	a.queue = append(a.queue, t)
+	if len(a.queue) == 1 {
+		close(t.turn)
+	} else {
+		log.Infof("admission: waiting for %d ports, %d in queue", n, len(a.queue))
+	}
+	a.mu.Unlock()
+	defer a.leave(t)
+
+	deadline := time.NewTimer(admissionTimeout())
+	defer deadline.Stop()
+	select {
+	case <-t.turn:
+	case <-deadline.C:
+		return nil, capacityExhausted("timed out in admission queue")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	for {
+		// 先取released, 预留失败后的release不会错过
+		a.mu.Lock()
+		released := a.released
+		a.mu.Unlock()
+		ports, err := reservePorts(n, owner)
+		if err != errPortPoolEmpty {
+			return ports, err
+		}
+		// 其它runner归还的端口不会通知到这里, 定期重试
+		select {
+		case <-released:
+		case <-time.After(admissionRetryInterval):
+		case <-deadline.C:
+			return nil, capacityExhausted("timed out in admission queue")
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// leave 离开队列, 队首离开时轮到下一个
+func (a *admission) leave(t *admissionTicket) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i, w := range a.queue {
+		if w == t {
+			a.queue = append(a.queue[:i], a.queue[i+1:]...)
+			if i == 0 && len(a.queue) > 0 {
+				close(a.queue[0].turn)
+			}
+			return
+		}
+	}
+}
+
+// release 有端口归还时唤醒队首
+func (a *admission) release() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	close(a.released)
+	a.released = make(chan struct{})
+}
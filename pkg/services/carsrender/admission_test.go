@@ -0,0 +1,172 @@
+package carsrender
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	merrors "github.com/micro/go-micro/v2/errors"
+)
+
+const testOwner = "test"
+
+// usePorts 测试期间使用内存端口池, 返回恢复原端口池的函数
+func usePorts(ports ...int) func() {
+	old := pl
+	pl = newPortPool("memory", ports, time.Minute)
+	return func() {
+		pl = old
+	}
+}
+
+type admitResult struct {
+	ports []int
+	err   error
+}
+
+func admitAsync(a *admission, ctx context.Context, n int) chan admitResult {
+	ch := make(chan admitResult, 1)
+	go func() {
+		ports, err := a.admit(ctx, n, testOwner)
+		ch <- admitResult{ports, err}
+	}()
+	return ch
+}
+
+// waitQueue 等待队列长度达到n
+func waitQueue(t *testing.T, a *admission, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		a.mu.Lock()
+		l := len(a.queue)
+		a.mu.Unlock()
+		if l == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("admission queue did not reach %d", n)
+}
+
+// giveBack 归还端口并唤醒队首
+func giveBack(a *admission, ports ...int) {
+	for _, port := range ports {
+		pl.push(port, testOwner)
+	}
+	a.release()
+}
+
+func receive(t *testing.T, ch chan admitResult) admitResult {
+	t.Helper()
+	select {
+	case r := <-ch:
+		return r
+	case <-time.After(time.Second):
+		t.Fatal("admit did not return")
+	}
+	return admitResult{}
+}
+
+func TestReservePortsRollsBack(t *testing.T) {
+	defer usePorts(9001)()
+	if _, err := reservePorts(2, testOwner); err != errPortPoolEmpty {
+		t.Fatalf("reservePorts = %v, want errPortPoolEmpty", err)
+	}
+	if free, _ := pl.free(); free != 1 {
+		t.Fatalf("free = %d after rollback, want 1", free)
+	}
+}
+
+func TestAdmissionFIFO(t *testing.T) {
+	defer usePorts(9001, 9002)()
+	a := newAdmission()
+	held, err := a.admit(context.Background(), 2, testOwner)
+	if err != nil || len(held) != 2 {
+		t.Fatalf("admit = %v, %v, want 2 ports", held, err)
+	}
+	first := admitAsync(a, context.Background(), 2)
+	waitQueue(t, a, 1)
+	second := admitAsync(a, context.Background(), 1)
+	waitQueue(t, a, 2)
+
+	// 一个端口足够second, 但first在队首
+	giveBack(a, held[0])
+	select {
+	case r := <-second:
+		t.Fatalf("second admitted before first: %v", r)
+	case <-time.After(100 * time.Millisecond):
+	}
+	giveBack(a, held[1])
+	r := receive(t, first)
+	if r.err != nil || len(r.ports) != 2 {
+		t.Fatalf("first = %v, %v, want 2 ports", r.ports, r.err)
+	}
+	giveBack(a, r.ports[0])
+	if r := receive(t, second); r.err != nil || len(r.ports) != 1 {
+		t.Fatalf("second = %v, %v, want 1 port", r.ports, r.err)
+	}
+}
+
+func TestAdmissionQueueFull(t *testing.T) {
+	defer usePorts(9001)()
+	conf.Set("task.cars.render.admission.queueSize", 1)
+	defer conf.Set("task.cars.render.admission.queueSize", 0)
+	a := newAdmission()
+	if _, err := a.admit(context.Background(), 1, testOwner); err != nil {
+		t.Fatalf("admit: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	head := admitAsync(a, ctx, 1)
+	waiting := admitAsync(a, ctx, 1)
+	waitQueue(t, a, 2)
+	_, err := a.admit(ctx, 1, testOwner)
+	cancel()
+	receive(t, head)
+	receive(t, waiting)
+	if merr := merrors.Parse(err.Error()); merr.Id != ErrCapacityExhausted || merr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("admit on a full queue = %v, want %s", err, ErrCapacityExhausted)
+	}
+}
+
+func TestAdmissionTimeout(t *testing.T) {
+	defer usePorts(9001)()
+	conf.Set("task.cars.render.admission.timeout", 1)
+	defer conf.Set("task.cars.render.admission.timeout", 0)
+	a := newAdmission()
+	if _, err := a.admit(context.Background(), 1, testOwner); err != nil {
+		t.Fatalf("admit: %v", err)
+	}
+	start := time.Now()
+	_, err := a.admit(context.Background(), 1, testOwner)
+	if merr := merrors.Parse(err.Error()); merr.Id != ErrCapacityExhausted {
+		t.Fatalf("admit = %v, want %s", err, ErrCapacityExhausted)
+	}
+	if waited := time.Since(start); waited < time.Second {
+		t.Fatalf("admit returned after %v, want the 1s timeout", waited)
+	}
+	waitQueue(t, a, 0)
+}
+
+func TestAdmissionCancelPassesTurn(t *testing.T) {
+	defer usePorts(9001)()
+	a := newAdmission()
+	held, err := a.admit(context.Background(), 1, testOwner)
+	if err != nil {
+		t.Fatalf("admit: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	first := admitAsync(a, ctx, 1)
+	waitQueue(t, a, 1)
+	second := admitAsync(a, context.Background(), 1)
+	waitQueue(t, a, 2)
+	cancel()
+	if r := receive(t, first); r.err != context.Canceled {
+		t.Fatalf("first = %v, want context.Canceled", r.err)
+	}
+	giveBack(a, held...)
+	if r := receive(t, second); r.err != nil || len(r.ports) != 1 {
+		t.Fatalf("second = %v, %v, want 1 port", r.ports, r.err)
+	}
+}
@@ -4,16 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
+	merrors "github.com/micro/go-micro/v2/errors"
 	uuid "github.com/satori/go.uuid"
 
-	fsdk "git.keyayun.com/bohaoc/seal-file-sdk"
-	"github.com/gorilla/websocket"
-	cbytes "github.com/micro/go-micro/v2/codec/bytes"
 	"keyayun.com/seal-micro-runner/pkg/config"
 	"keyayun.com/seal-micro-runner/pkg/logger"
 	pb "keyayun.com/seal-micro-runner/pkg/proto"
@@ -22,68 +20,54 @@ import (
 	"keyayun.com/seal-micro-runner/pkg/services"
 )
 
-type prepareParams struct {
-	port       int
-	workItemID string
-	baseURI    string
-
-	ready chan struct{}
-	ctx   context.Context
-	ws    *websocket.Conn
+// renderWorker 一个stream对应的render端口
+type renderWorker struct {
+	port    int
+	session *session
+	backend *backend
+	bridge  *bridge
+	rtt     *rttStats
 }
 
 type carsRenderService struct {
 	manifest *pb.ManifestInfo
-	client   *sealclient.SealClient
+	clients  *clientCache
 
-	workers     map[string]*prepareParams
-	workerPreCh chan *prepareParams
+	workers     map[string]*renderWorker
+	active      map[string]*session
+	workerPreCh chan *session
+	admission   *admission
 	mu          sync.Mutex
 
 	serverID string
 	rootPath string
+	draining int32
+	// starting 正在执行的Start数, 由mu保护
+	starting int
 }
 
-const timeout = 10
+const (
+	timeout               = 10
+	defaultPrepareTimeout = 300
+	defaultRootPath       = "/mnt"
+	defaultViewports      = 4
+	reapInterval          = time.Second * 6
+)
+
+// 同一(domain, workItemID)重复Start时的处理方式
+const (
+	startReuse   = "reuse"
+	startReplace = "replace"
+)
 
 var (
 	log  = logger.WithNamespace("cars.render")
 	conf = config.Config
-	pl   = new(portPool)
+	pl   portPool
 )
 
-type portPool struct {
-	items []int
-	lock  sync.RWMutex
-}
-
-func (pl *portPool) pull() (int, error) {
-	nLen := len(pl.items)
-	if nLen == 0 {
-		return 0, errors.New("PortPool Is Empty")
-	}
-	pl.lock.Lock()
-	item := pl.items[nLen-1]
-	pl.items = pl.items[:len(pl.items)-1]
-	pl.lock.Unlock()
-	return item, nil
-}
-
-func (pl *portPool) push(port int) {
-	pl.lock.Lock()
-	pl.items = append(pl.items, port)
-	pl.lock.Unlock()
-}
-
-func (pl *portPool) set(port int) {
-	pl.items = append(pl.items, port)
-}
-
 func init() {
-	var ports = conf.GetIntSlice("task.cars.render.ports")
-	for _, port := range ports {
-		pl.set(port)
-	}
+	pl = newPortPool(conf.GetString("task.cars.render.portPool.type"), conf.GetIntSlice("task.cars.render.ports"), portLeaseTTL())
 }
 
 func NewCarsRenderService() *carsRenderService {
@@ -105,47 +89,127 @@ func NewCarsRenderService() *carsRenderService {
 					Type:        "string",
 					Description: "keyayun.seal.workItem DocID",
 				},
+				{
+					Name:        "viewports",
+					Type:        "int",
+					Description: "number of render viewports",
+				},
+				{
+					Name:        "layout",
+					Type:        "string",
+					Description: "viewport layout or profile name",
+				},
 			},
 		},
-		workers:     make(map[string]*prepareParams),
-		workerPreCh: make(chan *prepareParams),
-		rootPath:    "/mnt",
+		workers:     make(map[string]*renderWorker),
+		active:      make(map[string]*session),
+		workerPreCh: make(chan *session),
+		admission:   newAdmission(),
+		rootPath:    RootPath(),
 	}
+	s.clients = newClientCache(s.manifest.Name)
 	return s
 }
 
 func (c *carsRenderService) InitService(serverID string) {
 	c.serverID = serverID
 	go func() {
-		for param := range c.workerPreCh {
-			fmt.Println(param.workItemID)
-			close(param.ready)
+		for s := range c.workerPreCh {
+			go c.prepareWorkspace(s)
 		}
 	}()
 
 	go func() {
 		for {
-			time.Sleep(time.Second * 6)
-			var ids []string
-			for id, v := range c.workers {
-				if v.port > 0 && v.ws == nil {
-					ids = append(ids, id)
+			time.Sleep(pl.ttl() / 3)
+			c.renewPorts()
+		}
+	}()
+
+	go func() {
+		for {
+			time.Sleep(reapInterval)
+			now := time.Now()
+			for _, s := range c.sessions() {
+				if reason := s.expiry(now); reason != "" {
+					c.stopSession(s, stateExpired, reason)
 				}
 			}
-			for _, id := range ids {
-				c.delPreParams(id)
-			}
 		}
 	}()
 }
 
-func (c *carsRenderService) putPreParams(id string, req *prepareParams) {
+// prepareTimeout 工作目录准备的超时时间
+func prepareTimeout() time.Duration {
+	if t := conf.GetInt("task.cars.render.prepareTimeout"); t > 0 {
+		return time.Second * time.Duration(t)
+	}
+	return time.Second * defaultPrepareTimeout
+}
+
+// RootPath 会话工作目录和录制文件所在的目录
+func RootPath() string {
+	if p := conf.GetString("task.cars.render.rootPath"); p != "" {
+		return p
+	}
+	return defaultRootPath
+}
+
+// viewportsOf 校验StartRequest的viewport数量和layout, 未指定时使用配置的默认值
+// 空闲端口不足时由admission排队等待
+func viewportsOf(req *pb.StartRequest) (int, string, error) {
+	n := int(req.Viewports)
+	if n == 0 {
+		n = conf.GetInt("task.cars.render.viewports.default")
+	}
+	if n == 0 {
+		n = defaultViewports
+	}
+	max := conf.GetInt("task.cars.render.viewports.max")
+	if max <= 0 || max > pl.size() {
+		max = pl.size()
+	}
+	if n < 1 || n > max {
+		return 0, "", fmt.Errorf("viewports %d out of range [1, %d]", n, max)
+	}
+	layout := req.Layout
+	if layout == "" {
+		layout = conf.GetString("task.cars.render.viewports.layout")
+	}
+	layouts := conf.GetStringSlice("task.cars.render.viewports.layouts")
+	if len(layouts) == 0 {
+		return n, layout, nil
+	}
+	for _, l := range layouts {
+		if l == layout {
+			return n, layout, nil
+		}
+	}
+	return 0, "", fmt.Errorf("unknown layout %q", layout)
+}
+
+// putPreParams 为会话登记预留的端口, 会话已被停止时归还端口
+func (c *carsRenderService) putPreParams(s *session, ports []int) ([]string, error) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.workers[id] = req
+	if c.active[s.key()] != s {
+		c.mu.Unlock()
+		for _, port := range ports {
+			pl.push(port, c.serverID)
+		}
+		c.admission.release()
+		return nil, fmt.Errorf("session(%s) was stopped while reserving ports", s.id)
+	}
+	ids := make([]string, 0, len(ports))
+	for _, port := range ports {
+		id := uuid.NewV4().String()
+		c.workers[id] = &renderWorker{port: port, session: s, rtt: &rttStats{}}
+		ids = append(ids, id)
+	}
+	c.mu.Unlock()
+	return ids, nil
 }
 
-func (c *carsRenderService) getPreParams(id string) (*prepareParams, error) {
+func (c *carsRenderService) getPreParams(id string) (*renderWorker, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	if r, ok := c.workers[id]; ok {
@@ -156,30 +220,120 @@ func (c *carsRenderService) getPreParams(id string) (*prepareParams, error) {
 
 func (c *carsRenderService) delPreParams(id string) error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-	param := c.workers[id]
-	if param == nil {
+	worker := c.workers[id]
+	if worker == nil {
+		c.mu.Unlock()
 		return os.ErrNotExist
 	}
-	pl.push(c.workers[id].port)
-	if param.ws != nil {
-		param.ws.Close()
-		param.ws = nil
-	}
 	delete(c.workers, id)
+	last := true
+	for _, w := range c.workers {
+		if w.session == worker.session {
+			last = false
+			break
+		}
+	}
+	c.mu.Unlock()
+	c.releaseWorker(worker)
+	if last {
+		c.stopSession(worker.session, stateStopped, "stopped by client")
+	}
 	return nil
 }
 
-func (c *carsRenderService) setClient(token *pb.TokenModel) {
-	c.client = &sealclient.SealClient{
-		SealClient: fsdk.SealClient{
-			Authorizer: &fsdk.BearerAuthorizer{Token: token.AccessToken},
-			Scheme:     token.Scheme,
-			Domain:     token.Domain,
-			HTTPClient: &http.Client{Timeout: time.Second * sealclient.HttpClientTimeOut},
-		},
-		Token: token,
+// releaseWorker 关闭backend连接, 结束backend后归还端口
+// worker需已从c.workers中移除, close和kill可能阻塞, 调用方不能持有c.mu
+func (c *carsRenderService) releaseWorker(worker *renderWorker) {
+	if worker.bridge != nil {
+		worker.bridge.close(nil)
+	}
+	log.Infof("session(%s) port(%d) stats: %s", worker.session.id, worker.port, worker.rtt.summary())
+	worker.backend.kill()
+	pl.push(worker.port, c.serverID)
+	c.admission.release()
+}
+
+// renewPorts 为活动的stream续租端口, 租约丢失的会话直接失败
+func (c *carsRenderService) renewPorts() {
+	c.mu.Lock()
+	workers := make([]*renderWorker, 0, len(c.workers))
+	for _, w := range c.workers {
+		workers = append(workers, w)
+	}
+	c.mu.Unlock()
+	for _, w := range workers {
+		err := pl.renew(w.port, c.serverID)
+		if err != nil {
+			log.Errorf("carsRenderService renew port(%d) of session(%s) failed: %v", w.port, w.session.id, err)
+			if err == errPortLeaseLost {
+				c.stopSession(w.session, stateFailed, fmt.Sprintf("lease of port %d lost", w.port))
+			}
+		}
+	}
+}
+
+// sessions 当前所有未结束的会话
+func (c *carsRenderService) sessions() []*session {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	list := make([]*session, 0, len(c.active))
+	for _, s := range c.active {
+		list = append(list, s)
 	}
+	return list
+}
+
+// sessionInfo 会话信息以及其stream的端口和队列深度
+func (c *carsRenderService) sessionInfo(s *session) *pb.SessionInfo {
+	info := s.info()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, w := range c.workers {
+		if w.session == s {
+			info.Sids = append(info.Sids, id)
+			info.Ports = append(info.Ports, int32(w.port))
+			info.Viewports = append(info.Viewports, w.rtt.snapshot(id, w.port))
+			if w.bridge != nil {
+				toClient, toBackend, dropped, observers := w.bridge.stats()
+				info.ClientQueue += int32(toClient)
+				info.BackendQueue += int32(toBackend)
+				info.DroppedFrames += dropped
+				info.Observers += int32(observers)
+			}
+		}
+	}
+	return info
+}
+
+// stopSession 释放会话的全部stream, 清理工作目录后进入final状态
+func (c *carsRenderService) stopSession(s *session, final sessionState, reason string) {
+	if s.stopping(reason) != nil {
+		return
+	}
+	var workers []*renderWorker
+	c.mu.Lock()
+	for id, w := range c.workers {
+		if w.session == s {
+			workers = append(workers, w)
+			delete(c.workers, id)
+		}
+	}
+	if c.active[s.key()] == s {
+		delete(c.active, s.key())
+	}
+	c.mu.Unlock()
+	for _, w := range workers {
+		c.releaseWorker(w)
+	}
+	s.cancel()
+	err := s.recorder.save(recordDir(c.rootPath), s.id)
+	if err != nil {
+		log.Errorf("carsRenderService save recording of session(%s) failed: %v", s.id, err)
+	}
+	os.RemoveAll(s.workspace)
+	s.transit(final)
+	log.Infof("session(%s) workItemID(%s) stats: %s", s.id, s.workItemID, s.stats.summary())
+	c.releaseWorkItem(s, final, reason)
 }
 
 func (c *carsRenderService) Manifest(_ context.Context, _ *pb.ManifestRequest, rsp *pb.ManifestInfo) error {
@@ -205,6 +359,7 @@ func (c *carsRenderService) Register(ctx context.Context, req *pb.TokenModel, rs
 		log.Errorf("carsRenderService Register failed when RegisterInstance: %s", err)
 		return err
 	}
+	c.clients.put(req)
 	return nil
 }
 
@@ -216,6 +371,7 @@ func (c *carsRenderService) Update(ctx context.Context, req *pb.TokenModel, rsp
 		log.Errorf("carsRenderService Register failed when RegisterInstance: %s", err)
 		return err
 	}
+	c.clients.put(req)
 	return nil
 }
 
@@ -227,62 +383,213 @@ func (c *carsRenderService) UnRegister(ctx context.Context, req *pb.TokenModel,
 		log.Errorf("carsRenderService Register failed when RegisterInstance: %s", err)
 		return err
 	}
+	c.clients.remove(req.Domain)
 	return nil
 }
 
 func (c *carsRenderService) Start(_ context.Context, req *pb.StartRequest, rsp *pb.StartResponse) error {
-	tokenID := c.manifest.Name + "_" + req.Domain
-	token, err := redis.GetInstance(tokenID)
+	c.mu.Lock()
+	if c.isDraining() {
+		c.mu.Unlock()
+		return errShuttingDown()
+	}
+	c.starting++
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.starting--
+		c.mu.Unlock()
+	}()
+	_, err := c.clients.get(req.Domain)
+	if err != nil {
+		log.Errorf("carsRenderService Start failed: %v", err)
+		return err
+	}
+	c.mu.Lock()
+	old := c.active[req.Domain+"/"+req.WorkItemID]
+	if old != nil && startMode() == startReuse {
+		c.mu.Unlock()
+		return c.reuseSession(old, rsp)
+	}
+	s := newSession(c.rootPath, req.Domain, req.WorkItemID, req.BaseWSlink, c.clients)
+	c.active[s.key()] = s
+	c.mu.Unlock()
+	defer close(s.started)
+	if old != nil {
+		// 新会话接管transaction, workitem保持IN PROGRESS
+		s.setTransaction(old.handOff())
+		c.stopSession(old, stateStopped, "replaced by a new Start")
+	}
+	viewports, layout, err := viewportsOf(req)
+	if err != nil {
+		log.Errorf("carsRenderService Start failed: %v", err)
+		c.stopSession(s, stateFailed, err.Error())
+		return err
+	}
+	s.layout = layout
+	s.transit(statePreparing)
+	ports, err := c.admission.admit(s.ctx, viewports, c.serverID)
+	if err != nil {
+		log.Errorf("carsRenderService Start failed: %v", err)
+		c.stopSession(s, stateFailed, err.Error())
+		return err
+	}
+	uids, err := c.putPreParams(s, ports)
 	if err != nil {
 		log.Errorf("carsRenderService Start failed: %v", err)
 		return err
 	}
-	c.setClient(token)
-	ctx, cancel := context.WithCancel(context.TODO())
-	param := &prepareParams{
-		ready:      make(chan struct{}),
-		ctx:        ctx,
-		workItemID: req.WorkItemID,
-		baseURI:    req.BaseWSlink,
-	}
-	streamUris := make([]string, 4)
-	stopUris := make([]string, 4)
-	ports := make([]int, 4)
+	// 预留到端口后才开始下载, 排队中的Start不占用rootPath
 	select {
-	case c.workerPreCh <- param:
-		for i := 0; i < 4; i++ {
-			uid := uuid.NewV4().String()
-			port, err := pl.pull()
-			if err != nil {
-				return err
-			}
-			streamUrl := fmt.Sprintf("%sServices.Stream?_id=%s&_sid=%s", r.baseURI, c.serverID, uid)
-			stopUrl := fmt.Sprintf("%sServices.Stop?_id=%s&_sid=%s", r.baseURI, c.serverID, uid)
-			param.port = port
-			ports[i] = port
-			streamUris[i] = streamUrl
-			stopUris[i] = stopUrl
-			c.putPreParams(uid, param)
-		}
+	case c.workerPreCh <- s:
 	case <-time.After(time.Second * timeout):
-		return errors.New(fmt.Sprintf("workspace prepare failed: workItemID(%s)", param.workItemID))
+		c.stopSession(s, stateFailed, "workspace preparer is busy")
+		return fmt.Errorf("workspace prepare failed: workItemID(%s)", s.workItemID)
+	}
+	streamUris := make([]string, viewports)
+	observeUris := make([]string, viewports)
+	stopUris := make([]string, viewports)
+	for i, uid := range uids {
+		streamUris[i] = c.streamURL(s, uid)
+		observeUris[i] = c.observeURL(s, uid)
+		stopUris[i] = c.stopURL(s, uid)
 	}
 	select {
-	case <-param.ready:
+	case <-s.ready:
+		if s.err != nil {
+			c.stopSession(s, stateFailed, s.err.Error())
+			return fmt.Errorf("workspace prepare failed: workItemID(%s): %v", s.workItemID, s.err)
+		}
+		if recordEnabled() {
+			s.recorder, err = newRecorder(filepath.Join(s.workspace, recordFile))
+			if err != nil {
+				log.Errorf("carsRenderService Start create recorder failed: %v", err)
+			}
+		}
+		err = c.launchBackends(s, uids)
+		if err != nil {
+			c.stopSession(s, stateFailed, err.Error())
+			return fmt.Errorf("render backend start failed: workItemID(%s): %v", s.workItemID, err)
+		}
+		err = s.transit(stateReady)
+		if err != nil {
+			return err
+		}
+		s.sids = uids
+		err = c.claimWorkItem(s)
+		if err != nil {
+			c.stopSession(s, stateFailed, err.Error())
+			return err
+		}
 		rsp.StreamUrls = streamUris
+		rsp.ObserveUrls = observeUris
 		rsp.StopUrls = stopUris
-		//fmt.Println(ready.Workspace)
-		fmt.Println("go")
-	case <-time.After(time.Second * timeout):
-		cancel()
-		return errors.New("docker start up failed")
+	case <-time.After(prepareTimeout()):
+		c.stopSession(s, stateFailed, "workspace prepare timeout")
+		return fmt.Errorf("workspace prepare timeout: workItemID(%s)", s.workItemID)
 	}
 	return nil
 }
 
+// startMode 重复Start的处理方式, 默认返回已有会话
+func startMode() string {
+	if mode := conf.GetString("task.cars.render.duplicateStart"); mode == startReplace {
+		return mode
+	}
+	return startReuse
+}
+
+func (c *carsRenderService) streamURL(s *session, sid string) string {
+	return c.signedURL(s, endpointStream, streamPurpose(roleController), sid)
+}
+
+// observeURL 只读的stream url, 角色包含在签名中
+func (c *carsRenderService) observeURL(s *session, sid string) string {
+	return c.signedURL(s, endpointStream, streamPurpose(roleObserver), sid) + "&role=" + roleObserver
+}
+
+func (c *carsRenderService) stopURL(s *session, sid string) string {
+	return c.signedURL(s, endpointStop, endpointStop, sid)
+}
+
+// signedURL 带有效期和签名的url, Stream和Stop会校验
+func (c *carsRenderService) signedURL(s *session, endpoint, purpose, sid string) string {
+	expires := time.Now().Add(urlTTL()).Unix()
+	return fmt.Sprintf("%s%s?_id=%s&_sid=%s&_exp=%d&_sig=%s", s.baseURI, endpoint, c.serverID, sid, expires, signURL(c.serverID, purpose, sid, s.domain, expires))
+}
+
+// reuseSession 等待正在准备的会话, 返回其仍然存在的stream的url
+func (c *carsRenderService) reuseSession(s *session, rsp *pb.StartResponse) error {
+	log.Infof("carsRenderService Start reuses session(%s) of workItemID(%s)", s.id, s.workItemID)
+	select {
+	case <-s.started:
+	case <-time.After(prepareTimeout()):
+		return fmt.Errorf("session(%s) of workItemID(%s) is still preparing", s.id, s.workItemID)
+	}
+	info := s.info()
+	if info.State != stateReady && info.State != stateStreaming {
+		return fmt.Errorf("session(%s) of workItemID(%s) is %s", s.id, s.workItemID, info.State)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, sid := range s.sids {
+		if _, ok := c.workers[sid]; ok {
+			rsp.StreamUrls = append(rsp.StreamUrls, c.streamURL(s, sid))
+			rsp.ObserveUrls = append(rsp.ObserveUrls, c.observeURL(s, sid))
+			rsp.StopUrls = append(rsp.StopUrls, c.stopURL(s, sid))
+		}
+	}
+	return nil
+}
+
+// launchBackends 为每个stream的端口启动render进程
+func (c *carsRenderService) launchBackends(s *session, ids []string) error {
+	errCh := make(chan error, len(ids))
+	for _, id := range ids {
+		worker, err := c.getPreParams(id)
+		if err != nil {
+			errCh <- err
+			continue
+		}
+		go func(id string, w *renderWorker) {
+			b, err := launchBackend(w.port, s.workspace, s.layout)
+			if err == nil {
+				c.mu.Lock()
+				attached := c.workers[id] == w
+				if attached {
+					w.backend = b
+				}
+				c.mu.Unlock()
+				if !attached {
+					// stream已被Stop
+					b.kill()
+				}
+			}
+			errCh <- err
+		}(id, worker)
+	}
+	var lastErr error
+	for range ids {
+		if err := <-errCh; err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
 func (c *carsRenderService) Stop(ctx context.Context, req *pb.StopRequest, rsp *pb.StopResponse) error {
 	defer log.Infof("End.Stop")
-	err := c.delPreParams(req.XSid)
+	worker, err := c.getPreParams(req.XSid)
+	if err != nil {
+		log.Errorf("carsRenderService Stop failed: %v", err)
+		return err
+	}
+	err = verifyURL(c.serverID, endpointStop, req.XSid, worker.session.domain, req.XExp, req.XSig)
+	if err != nil {
+		log.Errorf("carsRenderService Stop failed: %v", err)
+		return err
+	}
+	err = c.delPreParams(req.XSid)
 	if err != nil {
 		log.Errorf("carsRenderService Stop failed: %v", err)
 		return err
@@ -290,69 +597,79 @@ func (c *carsRenderService) Stop(ctx context.Context, req *pb.StopRequest, rsp *
 	return nil
 }
 
-func (c *carsRenderService) Stream(ctx context.Context, stream pb.Services_StreamStream) error {
-	data, err := stream.Recv()
+func (c *carsRenderService) ListSessions(ctx context.Context, _ *pb.ListSessionsRequest, rsp *pb.ListSessionsResponse) error {
+	err := checkAdmin(ctx)
 	if err != nil {
-		log.Errorf("carsRenderService Stream.Recv failed: %v", err)
+		log.Errorf("carsRenderService ListSessions failed: %v", err)
 		return err
 	}
-	param, err := c.getPreParams(data.XSid)
+	for _, s := range c.sessions() {
+		rsp.Sessions = append(rsp.Sessions, c.sessionInfo(s))
+	}
+	return nil
+}
+
+// findSession 按会话id或stream sid查找活动会话
+func (c *carsRenderService) findSession(id string) *session {
+	if worker, err := c.getPreParams(id); err == nil {
+		return worker.session
+	}
+	for _, s := range c.sessions() {
+		if s.id == id {
+			return s
+		}
+	}
+	return nil
+}
+
+func (c *carsRenderService) GetSession(ctx context.Context, req *pb.GetSessionRequest, rsp *pb.SessionInfo) error {
+	err := checkAdmin(ctx)
 	if err != nil {
-		log.Errorf("carsRenderService Stream getParam: %v", err)
+		log.Errorf("carsRenderService GetSession(%s) failed: %v", req.Id, err)
 		return err
 	}
+	found := c.findSession(req.Id)
+	if found == nil {
+		log.Errorf("carsRenderService GetSession(%s) failed: %v", req.Id, os.ErrNotExist)
+		return merrors.NotFound("carsRender.sessionNotFound", "session(%s) not found", req.Id)
+	}
+	info := c.sessionInfo(found)
+	rsp.Id = info.Id
+	rsp.WorkItemID = info.WorkItemID
+	rsp.Domain = info.Domain
+	rsp.Ports = info.Ports
+	rsp.State = info.State
+	rsp.CreatedAt = info.CreatedAt
+	rsp.LastActivity = info.LastActivity
+	rsp.Streams = info.Streams
+	rsp.Sids = info.Sids
+	rsp.ClientQueue = info.ClientQueue
+	rsp.BackendQueue = info.BackendQueue
+	rsp.DroppedFrames = info.DroppedFrames
+	rsp.Observers = info.Observers
+	rsp.Layout = info.Layout
+	rsp.Transactions = info.Transactions
+	rsp.Stats = info.Stats
+	rsp.Viewports = info.Viewports
+	return nil
+}
 
-	ws, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("ws://%s:%d/ws", conf.GetString("host"), param.port), nil)
+// StopSession 运维按会话id或stream sid结束会话, 以运维token代替stop url的签名
+func (c *carsRenderService) StopSession(ctx context.Context, req *pb.StopSessionRequest, rsp *pb.StopResponse) error {
+	err := checkAdmin(ctx)
 	if err != nil {
-		log.Errorf("carsRenderService Stream dial failed: %v", err)
+		log.Errorf("carsRenderService StopSession(%s) failed: %v", req.Id, err)
 		return err
 	}
-	param.ws = ws
-	defer ws.Close()
-	go func() {
-		defer stream.Close()
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-stream.Context().Done():
-				return
-			default:
-			}
-			_, message, err := ws.ReadMessage()
-			if err != nil {
-				log.Errorf("carsRenderService Stream ws recv failed: %v", err)
-				return
-			}
-			log.Printf("Stream ws recv: %s", string(message))
-			err = stream.SendMsg(&cbytes.Frame{Data: message})
-			if err != nil {
-				log.Errorf("carsRenderService stream write failed: %v", err)
-				return
-			}
-		}
-	}()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return nil
-		case <-stream.Context().Done():
-			return nil
-		default:
-		}
-		var data cbytes.Frame
-		err := stream.RecvMsg(&data)
-		if err != nil {
-			log.Errorf("carsRenderService stream recv failed: %v", err)
-			return err
-		}
-		log.Infof("carsRenderService stream.recv (%s)", string(data.Data))
-		err = ws.WriteMessage(websocket.TextMessage, data.Data)
-		if err != nil {
-			log.Errorf("carsRenderService ws write failed: %v", err)
-			return err
-		}
+	s := c.findSession(req.Id)
+	if s == nil {
+		log.Errorf("carsRenderService StopSession(%s) failed: %v", req.Id, os.ErrNotExist)
+		return merrors.NotFound("carsRender.sessionNotFound", "session(%s) not found", req.Id)
+	}
+	reason := "stopped by operator"
+	if req.Reason != "" {
+		reason += ": " + req.Reason
 	}
+	c.stopSession(s, stateStopped, reason)
 	return nil
 }
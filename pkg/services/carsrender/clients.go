@@ -0,0 +1,69 @@
+package carsrender
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	fsdk "git.keyayun.com/bohaoc/seal-file-sdk"
+	pb "keyayun.com/seal-micro-runner/pkg/proto"
+	"keyayun.com/seal-micro-runner/pkg/redis"
+	"keyayun.com/seal-micro-runner/pkg/sealclient"
+)
+
+// clientCache 按domain缓存SealClient, token变化时重建
+type clientCache struct {
+	name    string
+	clients map[string]*sealclient.SealClient
+	mu      sync.Mutex
+}
+
+func newClientCache(name string) *clientCache {
+	return &clientCache{name: name, clients: make(map[string]*sealclient.SealClient)}
+}
+
+func newSealClient(token *pb.TokenModel) *sealclient.SealClient {
+	return &sealclient.SealClient{
+		SealClient: fsdk.SealClient{
+			Authorizer: &fsdk.BearerAuthorizer{Token: token.AccessToken},
+			Scheme:     token.Scheme,
+			Domain:     token.Domain,
+			HTTPClient: &http.Client{Timeout: time.Second * sealclient.HttpClientTimeOut},
+		},
+		Token: token,
+	}
+}
+
+// get 返回domain的client, Redis中的token可能已被其它runner更新, 每次都与缓存比较
+func (cc *clientCache) get(domain string) (*sealclient.SealClient, error) {
+	token, err := redis.GetInstance(cc.name + "_" + domain)
+	if err != nil {
+		return nil, err
+	}
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if client := cc.clients[domain]; client != nil && client.Token.AccessToken == token.AccessToken {
+		return client, nil
+	}
+	return cc.putLocked(token), nil
+}
+
+// put Register/Update收到新token时重建client
+func (cc *clientCache) put(token *pb.TokenModel) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.putLocked(token)
+}
+
+func (cc *clientCache) putLocked(token *pb.TokenModel) *sealclient.SealClient {
+	client := newSealClient(token)
+	cc.clients[token.Domain] = client
+	log.Infof("clientCache(%s) client of domain(%s) rebuilt", cc.name, token.Domain)
+	return client
+}
+
+func (cc *clientCache) remove(domain string) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	delete(cc.clients, domain)
+}
@@ -0,0 +1,53 @@
+package carsrender
+
+// DICOM JSON中用到的tag, 前一组用于解析workitem引用的series, 后一组用于同步UPS状态
+const (
+	tagInputInformationSequence = "00404021"
+	tagStudyInstanceUID         = "0020000D"
+	tagSeriesInstanceUID        = "0020000E"
+	tagReferencedSOPSequence    = "00081199"
+	tagReferencedSOPInstanceUID = "00081155"
+	tagSOPInstanceUID           = "00080018"
+
+	tagTransactionUID        = "00081195"
+	tagProcedureStepState    = "00741000"
+	tagProgressInformation   = "00741002"
+	tagProcedureStepProgress = "00741004"
+	tagProgressDescription   = "00741006"
+	tagReasonForCancellation = "00741238"
+	tagCancellationDateTime  = "00404052"
+)
+
+const dicomDateTimeLayout = "20060102150405"
+
+func dicomAttr(vr string, values ...interface{}) map[string]interface{} {
+	return map[string]interface{}{"vr": vr, "Value": values}
+}
+
+func dicomValues(dataset map[string]interface{}, tag string) []interface{} {
+	attr, ok := dataset[tag].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	values, _ := attr["Value"].([]interface{})
+	return values
+}
+
+func dicomString(dataset map[string]interface{}, tag string) string {
+	values := dicomValues(dataset, tag)
+	if len(values) == 0 {
+		return ""
+	}
+	s, _ := values[0].(string)
+	return s
+}
+
+func dicomSequence(dataset map[string]interface{}, tag string) []map[string]interface{} {
+	var items []map[string]interface{}
+	for _, v := range dicomValues(dataset, tag) {
+		if item, ok := v.(map[string]interface{}); ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,128 @@
+package carsrender
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	pb "keyayun.com/seal-micro-runner/pkg/proto"
+)
+
+// heartbeatPayload runner自己发出的ping, 对应的pong不转发给客户端
+const heartbeatPayload = "runner-heartbeat"
+
+const (
+	defaultBackendPingInterval = 10
+	defaultBackendPongTimeout  = 30
+	// 客户端心跳需要客户端配合, 默认关闭
+	defaultClientInterval = 0
+	defaultClientTimeout  = 0
+)
+
+// heartbeat task.cars.render.heartbeat下的秒数配置, 配置为负数时关闭
+func heartbeat(key string, def int) time.Duration {
+	t := conf.GetInt("task.cars.render.heartbeat." + key)
+	if t < 0 {
+		return 0
+	}
+	if t == 0 {
+		t = def
+	}
+	return time.Second * time.Duration(t)
+}
+
+func backendPingInterval() time.Duration {
+	return heartbeat("backendInterval", defaultBackendPingInterval)
+}
+
+// backendPongTimeout backend在该时间内没有任何帧时认为连接已断开
+func backendPongTimeout() time.Duration {
+	return heartbeat("backendTimeout", defaultBackendPongTimeout)
+}
+
+func clientHeartbeatInterval() time.Duration {
+	return heartbeat("clientInterval", defaultClientInterval)
+}
+
+// clientHeartbeatTimeout controller在该时间内没有任何帧时结束会话, observer只断开自己
+func clientHeartbeatTimeout() time.Duration {
+	return heartbeat("clientTimeout", defaultClientTimeout)
+}
+
+// extendReadDeadline 收到backend的任意帧后延长读超时
+func (b *bridge) extendReadDeadline() {
+	if timeout := backendPongTimeout(); timeout > 0 {
+		b.ws.SetReadDeadline(time.Now().Add(timeout))
+	}
+}
+
+// pingBackend 定期向backend发送ping, 直到bridge关闭
+func (b *bridge) pingBackend() {
+	interval := backendPingInterval()
+	if interval == 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-ticker.C:
+			b.rtt.ping()
+			err := b.ws.WriteControl(websocket.PingMessage, []byte(heartbeatPayload), time.Now().Add(controlWriteWait))
+			if err != nil {
+				log.Errorf("session(%s) ping backend failed: %v", b.s.id, err)
+				b.lose(fmt.Sprintf("backend ping failed: %v", err))
+				return
+			}
+		}
+	}
+}
+
+// lose backend连接已不可用, 关闭bridge并结束会话
+func (b *bridge) lose(reason string) {
+	b.close(nil)
+	if b.lost != nil {
+		b.lost(reason)
+	}
+}
+
+func isTimeout(err error) bool {
+	ne, ok := err.(net.Error)
+	return ok && ne.Timeout()
+}
+
+// heartbeatClient 定期给客户端发送heartbeat为true的StreamData, 客户端以同样的帧回复
+// 客户端超时未发送任何帧时返回错误, controller超时视为连接已断开, 结束会话
+func (b *bridge) heartbeatClient(client *streamClient) error {
+	interval := clientHeartbeatInterval()
+	if interval == 0 {
+		<-client.done
+		return nil
+	}
+	timeout := clientHeartbeatTimeout()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-client.done:
+			return nil
+		case <-ticker.C:
+			idle := time.Since(time.Unix(0, atomic.LoadInt64(&client.lastSeen)))
+			if timeout > 0 && idle > timeout {
+				reason := fmt.Sprintf("%s stream sent no heartbeat for %v", client.role, idle)
+				log.Warnf("session(%s) %s", b.s.id, reason)
+				client.send(&pb.StreamData{MessageType: websocket.CloseMessage, CloseCode: websocket.CloseGoingAway, CloseText: "heartbeat timeout"})
+				if client.role == roleController {
+					b.lose(reason)
+				}
+				return errors.New(reason)
+			}
+			client.queue.push(&frame{data: &pb.StreamData{Heartbeat: true}})
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package carsrender
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultBackendReadyTimeout = 30
+	backendProbeInterval       = time.Millisecond * 200
+)
+
+// backend 绑定到端口池端口的render进程
+type backend struct {
+	port int
+	cmd  *exec.Cmd
+	done chan struct{}
+	err  error
+}
+
+func backendURL(port int) string {
+	return fmt.Sprintf("ws://%s:%d/ws", conf.GetString("host"), port)
+}
+
+// portInUse 端口上已有进程在监听, 例如崩溃的runner遗留的backend
+func portInUse(port int) bool {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", conf.GetString("host"), port), backendProbeInterval)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+func backendReadyTimeout() time.Duration {
+	if t := conf.GetInt("task.cars.render.backend.readyTimeout"); t > 0 {
+		return time.Second * time.Duration(t)
+	}
+	return time.Second * defaultBackendReadyTimeout
+}
+
+// launchBackend 启动render进程并等待/ws可连接
+// 未配置task.cars.render.backend.bin时只检查端口上已有的backend
+func launchBackend(port int, workspace, layout string) (*backend, error) {
+	b := &backend{port: port}
+	bin := conf.GetString("task.cars.render.backend.bin")
+	if bin != "" {
+		// 否则probe会连到旧的backend, 新进程启动失败也无法发现
+		if portInUse(port) {
+			err := fmt.Errorf("port %d is already in use by another process", port)
+			log.Errorf("launchBackend failed: %v", err)
+			return nil, err
+		}
+		replacer := strings.NewReplacer("{port}", strconv.Itoa(port), "{workspace}", workspace, "{layout}", layout)
+		var args []string
+		for _, arg := range conf.GetStringSlice("task.cars.render.backend.args") {
+			args = append(args, replacer.Replace(arg))
+		}
+		logFile, err := os.Create(filepath.Join(workspace, fmt.Sprintf("backend-%d.log", port)))
+		if err != nil {
+			log.Errorf("launchBackend(port: %d) failed when create log file: %v", port, err)
+			return nil, err
+		}
+		cmd := exec.Command(bin, args...)
+		cmd.Dir = workspace
+		cmd.Stdout = logFile
+		cmd.Stderr = logFile
+		cmd.SysProcAttr = backendProcAttr()
+		err = cmd.Start()
+		if err != nil {
+			logFile.Close()
+			log.Errorf("launchBackend(port: %d) failed when start %s: %v", port, bin, err)
+			return nil, err
+		}
+		log.Infof("launchBackend(port: %d) started %s, pid = %d", port, bin, cmd.Process.Pid)
+		b.cmd = cmd
+		b.done = make(chan struct{})
+		go func() {
+			b.err = cmd.Wait()
+			logFile.Close()
+			close(b.done)
+		}()
+	}
+	err := b.waitReady(backendReadyTimeout())
+	if err != nil {
+		log.Errorf("launchBackend failed: %v", err)
+		b.kill()
+		return nil, err
+	}
+	return b, nil
+}
+
+// waitReady 轮询/ws直到可以建立连接
+func (b *backend) waitReady(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		ws, _, err := websocket.DefaultDialer.Dial(backendURL(b.port), nil)
+		if err == nil {
+			ws.Close()
+			// 连上的不是刚启动的进程
+			select {
+			case <-b.done:
+				return fmt.Errorf("backend(port: %d) exited: %v", b.port, b.err)
+			default:
+			}
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("backend(port: %d) /ws is not accepting connections: %v", b.port, err)
+		}
+		select {
+		case <-b.done:
+			return fmt.Errorf("backend(port: %d) exited: %v", b.port, b.err)
+		case <-time.After(backendProbeInterval):
+		}
+	}
+}
+
+// kill 结束由launcher启动的进程及其进程组, 会等待进程退出
+func (b *backend) kill() {
+	if b == nil || b.cmd == nil {
+		return
+	}
+	select {
+	case <-b.done:
+		return
+	default:
+	}
+	err := syscall.Kill(-b.cmd.Process.Pid, syscall.SIGKILL)
+	if err != nil {
+		log.Errorf("backend(port: %d) kill failed: %v", b.port, err)
+		return
+	}
+	<-b.done
+	log.Infof("backend(port: %d) stopped", b.port)
+}
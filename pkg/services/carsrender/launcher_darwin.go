@@ -0,0 +1,8 @@
+package carsrender
+
+import "syscall"
+
+// backendProcAttr darwin没有Pdeathsig, 只在独立的进程组中运行
+func backendProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setpgid: true}
+}
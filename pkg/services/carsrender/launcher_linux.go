@@ -0,0 +1,8 @@
+package carsrender
+
+import "syscall"
+
+// backendProcAttr backend在独立的进程组中运行, runner退出时由内核结束
+func backendProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setpgid: true, Pdeathsig: syscall.SIGKILL}
+}
@@ -0,0 +1,164 @@
+package carsrender
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"keyayun.com/seal-micro-runner/pkg/redis"
+)
+
+const defaultPortLeaseTTL = 30
+
+var (
+	errPortPoolEmpty = errors.New("PortPool Is Empty")
+	errPortLeaseLost = errors.New("port lease is lost")
+)
+
+// portPool 以租约方式分配render端口, 租约需要在ttl内续租
+type portPool interface {
+	pull(owner string) (int, error)
+	push(port int, owner string)
+	renew(port int, owner string) error
+	free() (int, error)
+	size() int
+	ttl() time.Duration
+}
+
+func newPortPool(kind string, ports []int, ttl time.Duration) portPool {
+	switch kind {
+	case "redis":
+		return &redisPortPool{host: conf.GetString("host"), ports: ports, leaseTTL: ttl}
+	}
+	return &memoryPortPool{ports: ports, leases: make(map[int]*portLease), leaseTTL: ttl}
+}
+
+func portLeaseTTL() time.Duration {
+	if t := conf.GetInt("task.cars.render.portPool.leaseTTL"); t > 0 {
+		return time.Second * time.Duration(t)
+	}
+	return time.Second * defaultPortLeaseTTL
+}
+
+type portLease struct {
+	owner   string
+	expires time.Time
+}
+
+// memoryPortPool 进程内端口池
+type memoryPortPool struct {
+	ports    []int
+	leases   map[int]*portLease
+	leaseTTL time.Duration
+	lock     sync.Mutex
+}
+
+func (p *memoryPortPool) pull(owner string) (int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	now := time.Now()
+	for _, port := range p.ports {
+		lease := p.leases[port]
+		if lease == nil || now.After(lease.expires) {
+			p.leases[port] = &portLease{owner: owner, expires: now.Add(p.leaseTTL)}
+			return port, nil
+		}
+	}
+	return 0, errPortPoolEmpty
+}
+
+func (p *memoryPortPool) push(port int, owner string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if lease := p.leases[port]; lease != nil && lease.owner == owner {
+		delete(p.leases, port)
+	}
+}
+
+func (p *memoryPortPool) renew(port int, owner string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	lease := p.leases[port]
+	if lease == nil || lease.owner != owner || time.Now().After(lease.expires) {
+		return errPortLeaseLost
+	}
+	lease.expires = time.Now().Add(p.leaseTTL)
+	return nil
+}
+
+func (p *memoryPortPool) free() (int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	now := time.Now()
+	n := 0
+	for _, port := range p.ports {
+		lease := p.leases[port]
+		if lease == nil || now.After(lease.expires) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (p *memoryPortPool) size() int {
+	return len(p.ports)
+}
+
+func (p *memoryPortPool) ttl() time.Duration {
+	return p.leaseTTL
+}
+
+// redisPortPool 同一host上的多个runner通过Redis共享端口租约
+type redisPortPool struct {
+	host     string
+	ports    []int
+	leaseTTL time.Duration
+}
+
+func (p *redisPortPool) pull(owner string) (int, error) {
+	for _, i := range rand.Perm(len(p.ports)) {
+		ok, err := redis.LeasePort(p.host, p.ports[i], owner, p.leaseTTL)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return p.ports[i], nil
+		}
+	}
+	return 0, errPortPoolEmpty
+}
+
+func (p *redisPortPool) push(port int, owner string) {
+	err := redis.ReleasePort(p.host, port, owner)
+	if err != nil {
+		log.Errorf("redisPortPool push(port: %d) failed: %v", port, err)
+	}
+}
+
+func (p *redisPortPool) renew(port int, owner string) error {
+	ok, err := redis.RenewPort(p.host, port, owner, p.leaseTTL)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errPortLeaseLost
+	}
+	return nil
+}
+
+func (p *redisPortPool) free() (int, error) {
+	n, err := redis.CountLeasedPorts(p.host, p.ports)
+	if err != nil {
+		return 0, err
+	}
+	return len(p.ports) - n, nil
+}
+
+func (p *redisPortPool) size() int {
+	return len(p.ports)
+}
+
+func (p *redisPortPool) ttl() time.Duration {
+	return p.leaseTTL
+}
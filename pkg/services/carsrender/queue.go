@@ -0,0 +1,210 @@
+package carsrender
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	pb "keyayun.com/seal-micro-runner/pkg/proto"
+)
+
+type dropPolicy = string
+
+const (
+	dropNone   dropPolicy = "none"
+	dropOldest dropPolicy = "oldest"
+	dropNewest dropPolicy = "newest"
+)
+
+const (
+	defaultClientQueue  = 64
+	defaultBackendQueue = 256
+)
+
+var errQueueClosed = errors.New("frame queue closed")
+
+// frame 队列中的一帧, seq为backend数据帧的序号, 其它帧为0
+type frame struct {
+	seq  int64
+	data *pb.StreamData
+}
+
+// frameQueue 有界的帧队列, 满时按policy丢弃数据帧, close帧不会被丢弃
+type frameQueue struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	frames  []*frame
+	size    int
+	policy  dropPolicy
+	closed  bool
+	cause   error
+	dropped int64
+}
+
+func newFrameQueue(size int, policy dropPolicy) *frameQueue {
+	if size <= 0 {
+		size = 1
+	}
+	switch policy {
+	case dropNone, dropOldest, dropNewest:
+	default:
+		log.Warnf("unknown stream drop policy %q, frames will not be dropped", policy)
+		policy = dropNone
+	}
+	q := &frameQueue{size: size, policy: policy}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// clientQueueSize backend -> 客户端方向的队列长度
+func clientQueueSize() int {
+	if n := conf.GetInt("task.cars.render.stream.clientQueue"); n > 0 {
+		return n
+	}
+	return defaultClientQueue
+}
+
+// backendQueueSize 客户端 -> backend方向的队列长度
+func backendQueueSize() int {
+	if n := conf.GetInt("task.cars.render.stream.backendQueue"); n > 0 {
+		return n
+	}
+	return defaultBackendQueue
+}
+
+// clientDropPolicy 客户端消费过慢时render帧的丢弃策略, 输入方向从不丢弃
+func clientDropPolicy() dropPolicy {
+	if p := conf.GetString("task.cars.render.stream.dropPolicy"); p != "" {
+		return p
+	}
+	return dropOldest
+}
+
+// isDroppable 队列满时是否可以丢弃该帧, ping/pong和心跳总是可以丢弃
+func isDroppable(f *frame, policy dropPolicy) bool {
+	if isControlFrame(f) {
+		return f.data.MessageType != websocket.CloseMessage
+	}
+	return policy != dropNone
+}
+
+func isControlFrame(f *frame) bool {
+	if f.data.Heartbeat {
+		return true
+	}
+	switch f.data.MessageType {
+	case websocket.CloseMessage, websocket.PingMessage, websocket.PongMessage:
+		return true
+	}
+	return false
+}
+
+// push 入队, 队列满时dropOldest先丢弃最早的数据帧腾出空间, 否则丢弃可丢弃的新帧
+// close帧在允许丢帧的policy下可以超出队列长度, 只有policy为none时才会阻塞生产者
+func (q *frameQueue) push(f *frame) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for !q.closed && len(q.frames) >= q.size {
+		if q.policy == dropOldest && q.dropOldestData() {
+			continue
+		}
+		if isDroppable(f, q.policy) {
+			q.dropped++
+			return nil
+		}
+		if q.policy != dropNone {
+			break
+		}
+		q.cond.Wait()
+	}
+	if q.closed {
+		return errQueueClosed
+	}
+	q.frames = append(q.frames, f)
+	q.cond.Broadcast()
+	return nil
+}
+
+// dropOldestData 丢弃最早的数据帧, 队列中只有控制帧时返回false
+func (q *frameQueue) dropOldestData() bool {
+	for i, f := range q.frames {
+		if !isControlFrame(f) {
+			q.frames = append(q.frames[:i], q.frames[i+1:]...)
+			q.dropped++
+			return true
+		}
+	}
+	return false
+}
+
+// pop 出队, 队列为空时阻塞, 关闭后先取完剩余的帧
+func (q *frameQueue) pop() (*frame, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for !q.closed && len(q.frames) == 0 {
+		q.cond.Wait()
+	}
+	if len(q.frames) == 0 {
+		return nil, errQueueClosed
+	}
+	f := q.frames[0]
+	q.frames[0] = nil
+	q.frames = q.frames[1:]
+	q.cond.Broadcast()
+	return f, nil
+}
+
+// close 关闭队列, cause为生产者结束的原因, 消费者取完剩余帧后得到它
+func (q *frameQueue) close(cause error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.cause = cause
+	q.cond.Broadcast()
+}
+
+func (q *frameQueue) err() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.cause
+}
+
+// stats 当前深度和累计丢弃的帧数
+func (q *frameQueue) stats() (int, int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.frames), q.dropped
+}
+
+// frameRing 最近的backend数据帧, 重连后回放给客户端
+type frameRing struct {
+	frames []*frame
+	next   int
+}
+
+func newFrameRing(size int) *frameRing {
+	return &frameRing{frames: make([]*frame, size)}
+}
+
+func (r *frameRing) put(f *frame) {
+	if len(r.frames) == 0 {
+		return
+	}
+	r.frames[r.next] = f
+	r.next = (r.next + 1) % len(r.frames)
+}
+
+// since 按顺序返回序号大于seq的帧
+func (r *frameRing) since(seq int64) []*frame {
+	var list []*frame
+	for i := range r.frames {
+		f := r.frames[(r.next+i)%len(r.frames)]
+		if f != nil && f.seq > seq {
+			list = append(list, f)
+		}
+	}
+	return list
+}
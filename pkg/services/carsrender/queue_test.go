@@ -0,0 +1,158 @@
+package carsrender
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	pb "keyayun.com/seal-micro-runner/pkg/proto"
+)
+
+func dataFrame(seq int64) *frame {
+	return &frame{seq: seq, data: &pb.StreamData{MessageType: websocket.BinaryMessage}}
+}
+
+func controlFrame(messageType int32) *frame {
+	return &frame{data: &pb.StreamData{MessageType: messageType}}
+}
+
+// pushDone push在timeout内返回时为true
+func pushDone(q *frameQueue, f *frame, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		q.push(f)
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+func popAll(t *testing.T, q *frameQueue) []*frame {
+	t.Helper()
+	var frames []*frame
+	q.close(nil)
+	for {
+		f, err := q.pop()
+		if err != nil {
+			return frames
+		}
+		frames = append(frames, f)
+	}
+}
+
+func TestFrameQueueDropNewest(t *testing.T) {
+	q := newFrameQueue(2, dropNewest)
+	for i := int64(1); i <= 3; i++ {
+		if err := q.push(dataFrame(i)); err != nil {
+			t.Fatalf("push(%d): %v", i, err)
+		}
+	}
+	if _, dropped := q.stats(); dropped != 1 {
+		t.Fatalf("dropped = %d, want 1", dropped)
+	}
+	frames := popAll(t, q)
+	if len(frames) != 2 || frames[0].seq != 1 || frames[1].seq != 2 {
+		t.Fatalf("frames = %v, want seq 1 and 2", frames)
+	}
+}
+
+func TestFrameQueueDropOldest(t *testing.T) {
+	q := newFrameQueue(2, dropOldest)
+	for i := int64(1); i <= 3; i++ {
+		if err := q.push(dataFrame(i)); err != nil {
+			t.Fatalf("push(%d): %v", i, err)
+		}
+	}
+	frames := popAll(t, q)
+	if len(frames) != 2 || frames[0].seq != 2 || frames[1].seq != 3 {
+		t.Fatalf("frames = %v, want seq 2 and 3", frames)
+	}
+}
+
+func TestFrameQueueDropOldestEvictsDataForControl(t *testing.T) {
+	q := newFrameQueue(2, dropOldest)
+	q.push(controlFrame(websocket.PingMessage))
+	q.push(dataFrame(1))
+	if !pushDone(q, controlFrame(websocket.CloseMessage), time.Second) {
+		t.Fatal("close frame blocked on a full dropOldest queue")
+	}
+	frames := popAll(t, q)
+	if len(frames) != 2 || frames[0].data.MessageType != websocket.PingMessage || frames[1].data.MessageType != websocket.CloseMessage {
+		t.Fatalf("frames = %v, want ping and close", frames)
+	}
+}
+
+func TestFrameQueueControlDoesNotBlock(t *testing.T) {
+	q := newFrameQueue(1, dropNewest)
+	q.push(dataFrame(1))
+	if !pushDone(q, controlFrame(websocket.PingMessage), time.Second) {
+		t.Fatal("ping blocked on a full queue")
+	}
+	if !pushDone(q, controlFrame(websocket.CloseMessage), time.Second) {
+		t.Fatal("close blocked on a full queue")
+	}
+	frames := popAll(t, q)
+	if len(frames) != 2 || frames[1].data.MessageType != websocket.CloseMessage {
+		t.Fatalf("frames = %v, want the data frame and close", frames)
+	}
+}
+
+func TestFrameQueueDropNoneBlocks(t *testing.T) {
+	q := newFrameQueue(1, dropNone)
+	q.push(dataFrame(1))
+	done := make(chan struct{})
+	go func() {
+		q.push(dataFrame(2))
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("push did not wait on a full queue")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if f, _ := q.pop(); f.seq != 1 {
+		t.Fatalf("pop seq = %d, want 1", f.seq)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("push did not resume after pop")
+	}
+	if _, dropped := q.stats(); dropped != 0 {
+		t.Fatalf("dropped = %d, want 0", dropped)
+	}
+}
+
+func TestFrameQueueClose(t *testing.T) {
+	q := newFrameQueue(2, dropNone)
+	q.push(dataFrame(1))
+	q.close(errBridgeClosed)
+	if err := q.push(dataFrame(2)); err != errQueueClosed {
+		t.Fatalf("push after close = %v, want errQueueClosed", err)
+	}
+	if f, err := q.pop(); err != nil || f.seq != 1 {
+		t.Fatalf("pop = %v, %v, want the remaining frame", f, err)
+	}
+	if _, err := q.pop(); err != errQueueClosed {
+		t.Fatalf("pop on drained queue = %v, want errQueueClosed", err)
+	}
+	if q.err() != errBridgeClosed {
+		t.Fatalf("err = %v, want errBridgeClosed", q.err())
+	}
+}
+
+func TestFrameRingSince(t *testing.T) {
+	r := newFrameRing(3)
+	for i := int64(1); i <= 5; i++ {
+		r.put(dataFrame(i))
+	}
+	frames := r.since(3)
+	if len(frames) != 2 || frames[0].seq != 4 || frames[1].seq != 5 {
+		t.Fatalf("since(3) = %v, want seq 4 and 5", frames)
+	}
+}
@@ -0,0 +1,181 @@
+package carsrender
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	pb "keyayun.com/seal-micro-runner/pkg/proto"
+)
+
+const (
+	recordFile   = "session.rec"
+	dirToBackend = "in"
+	dirToClient  = "out"
+)
+
+// RecordEntry 录制文件中的一行, 对应Stream上的一帧
+type RecordEntry struct {
+	Sid         string `json:"sid"`
+	Direction   string `json:"dir"`
+	At          int64  `json:"at"`
+	MessageType int32  `json:"type"`
+	Data        []byte `json:"data,omitempty"`
+	CloseCode   int32  `json:"closeCode,omitempty"`
+	CloseText   string `json:"closeText,omitempty"`
+}
+
+// recorder 把会话所有stream的帧按行写入工作目录下的录制文件
+type recorder struct {
+	mu     sync.Mutex
+	path   string
+	file   *os.File
+	enc    *json.Encoder
+	closed bool
+}
+
+func recordEnabled() bool {
+	return conf.GetBool("task.cars.render.record.enabled")
+}
+
+// recordDir 会话结束后录制文件的保存目录, 工作目录会被删除
+func recordDir(rootPath string) string {
+	if dir := conf.GetString("task.cars.render.record.dir"); dir != "" {
+		return dir
+	}
+	return filepath.Join(rootPath, "recordings")
+}
+
+func newRecorder(path string) (*recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &recorder{path: path, file: file, enc: json.NewEncoder(file)}, nil
+}
+
+// record 写入一帧, 未开启录制时r为nil
+func (r *recorder) record(sid, direction string, data *pb.StreamData) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	err := r.enc.Encode(&RecordEntry{
+		Sid:         sid,
+		Direction:   direction,
+		At:          time.Now().UnixNano(),
+		MessageType: data.MessageType,
+		Data:        data.Data,
+		CloseCode:   data.CloseCode,
+		CloseText:   data.CloseText,
+	})
+	if err != nil {
+		log.Errorf("recorder write %s failed: %v", r.path, err)
+	}
+}
+
+// save 关闭录制文件并移动到dir/<name>.rec
+func (r *recorder) save(dir, name string) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	err := r.file.Close()
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+	return os.Rename(r.path, filepath.Join(dir, name+".rec"))
+}
+
+// ReadRecording 读取录制文件中的全部帧
+func ReadRecording(path string) ([]*RecordEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var entries []*RecordEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		entry := &RecordEntry{}
+		err = json.Unmarshal(scanner.Bytes(), entry)
+		if err != nil {
+			return nil, fmt.Errorf("%s: line %d: %v", path, len(entries)+1, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// Replay 把录制的客户端输入重放到backend的/ws
+// speed为1时保持原始间隔, 大于1时加速, 为0时不等待; sid为空时使用录制中第一个stream
+func Replay(path, sid, url string, speed float64) error {
+	entries, err := ReadRecording(path)
+	if err != nil {
+		return err
+	}
+	var inputs []*RecordEntry
+	for _, e := range entries {
+		if e.Direction != dirToBackend {
+			continue
+		}
+		if sid == "" {
+			sid = e.Sid
+		}
+		if e.Sid == sid {
+			inputs = append(inputs, e)
+		}
+	}
+	if len(inputs) == 0 {
+		return errors.New("no input frames to replay")
+	}
+	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return err
+	}
+	defer ws.Close()
+	var received int64
+	go func() {
+		for {
+			_, _, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+			atomic.AddInt64(&received, 1)
+		}
+	}()
+	log.Infof("replay %d frames of stream(%s) to %s", len(inputs), sid, url)
+	start := time.Now()
+	for i, e := range inputs {
+		if speed > 0 && i > 0 {
+			time.Sleep(time.Duration(float64(e.At-inputs[i-1].At) / speed))
+		}
+		err = writeFrame(ws, &pb.StreamData{MessageType: e.MessageType, Data: e.Data, CloseCode: e.CloseCode, CloseText: e.CloseText})
+		if err != nil {
+			return fmt.Errorf("replay frame %d failed: %v", i+1, err)
+		}
+	}
+	log.Infof("replay finished in %v: sent %d frames, received %d frames", time.Since(start), len(inputs), atomic.LoadInt64(&received))
+	return nil
+}
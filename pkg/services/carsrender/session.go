@@ -0,0 +1,288 @@
+package carsrender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	pb "keyayun.com/seal-micro-runner/pkg/proto"
+	"keyayun.com/seal-micro-runner/pkg/sealclient"
+)
+
+type sessionState = string
+
+const (
+	statePending     sessionState = "pending"
+	statePreparing   sessionState = "preparing"
+	stateReady       sessionState = "ready"
+	stateStreaming   sessionState = "streaming"
+	stateStopping    sessionState = "stopping"
+	stateStopped     sessionState = "stopped"
+	stateExpired     sessionState = "expired"
+	stateFailed      sessionState = "failed"
+	stateInterrupted sessionState = "interrupted"
+)
+
+const (
+	defaultConnectGrace = 60
+	defaultIdleTimeout  = 600
+	defaultMaxLifetime  = 14400
+)
+
+// sessionTransitions 允许的状态迁移, 终止状态只能经由stopping到达
+var sessionTransitions = map[sessionState][]sessionState{
+	statePending:   {statePreparing, stateStopping},
+	statePreparing: {stateReady, stateStopping},
+	stateReady:     {stateStreaming, stateStopping},
+	stateStreaming: {stateReady, stateStopping},
+	stateStopping:  {stateStopped, stateExpired, stateFailed, stateInterrupted},
+}
+
+type stateChange struct {
+	from sessionState
+	to   sessionState
+	at   time.Time
+}
+
+// session 一次Start对应的render会话, 包含工作目录和若干stream
+type session struct {
+	id         string
+	domain     string
+	workItemID string
+	baseURI    string
+	workspace  string
+	layout     string
+	clients    *clientCache
+	recorder   *recorder
+	stats      *sessionStats
+
+	ready  chan struct{}
+	err    error
+	ctx    context.Context
+	cancel context.CancelFunc
+	// started Start返回时关闭, sids为Start分配的stream
+	started chan struct{}
+	sids    []string
+
+	mu           sync.Mutex
+	state        sessionState
+	createdAt    time.Time
+	lastActivity time.Time
+	history      []stateChange
+	streams      int
+	reason       string
+	// transactionUID 当前持有的UPS transaction, transactions为会话用过的全部transaction
+	transactionUID string
+	transactions   []string
+}
+
+func newSession(rootPath, domain, workItemID, baseURI string, clients *clientCache) *session {
+	id := uuid.NewV4().String()
+	ctx, cancel := context.WithCancel(context.TODO())
+	now := time.Now()
+	s := &session{
+		id:           id,
+		domain:       domain,
+		workItemID:   workItemID,
+		baseURI:      baseURI,
+		workspace:    filepath.Join(rootPath, id),
+		clients:      clients,
+		stats:        &sessionStats{},
+		ready:        make(chan struct{}),
+		started:      make(chan struct{}),
+		ctx:          ctx,
+		cancel:       cancel,
+		state:        statePending,
+		createdAt:    now,
+		lastActivity: now,
+		history:      []stateChange{{to: statePending, at: now}},
+	}
+	log.Infof("session(%s) workItemID(%s) domain(%s): created", id, workItemID, domain)
+	return s
+}
+
+// sealClient 每次使用时从缓存取domain的client, 会话期间token被刷新后使用新的token
+func (s *session) sealClient() (*sealclient.SealClient, error) {
+	if s.clients == nil {
+		return nil, errors.New("seal client is not set")
+	}
+	return s.clients.get(s.domain)
+}
+
+func (s *session) transit(to sessionState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.transitLocked(to)
+}
+
+func (s *session) transitLocked(to sessionState) error {
+	for _, next := range sessionTransitions[s.state] {
+		if next == to {
+			s.lastActivity = time.Now()
+			s.history = append(s.history, stateChange{from: s.state, to: to, at: s.lastActivity})
+			log.Infof("session(%s) workItemID(%s): %s -> %s", s.id, s.workItemID, s.state, to)
+			s.state = to
+			return nil
+		}
+	}
+	err := fmt.Errorf("session(%s) can not transit from %s to %s", s.id, s.state, to)
+	log.Warn(err)
+	return err
+}
+
+// key 同一(domain, workItemID)只有一个活动会话
+func (s *session) key() string {
+	return s.domain + "/" + s.workItemID
+}
+
+// stopping 进入stopping并记录关闭原因, 原因会返回给仍连接的客户端
+func (s *session) stopping(reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.transitLocked(stateStopping)
+	if err != nil {
+		return err
+	}
+	s.reason = reason
+	log.Infof("session(%s) workItemID(%s): stopping, %s", s.id, s.workItemID, reason)
+	return nil
+}
+
+// closeReason 会话被关闭的原因, 未关闭时为空
+func (s *session) closeReason() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reason
+}
+
+func (s *session) setTransaction(uid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transactionUID = uid
+	if uid != "" {
+		s.transactions = append(s.transactions, uid)
+	}
+}
+
+func (s *session) transaction() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.transactionUID
+}
+
+// handOff 交出当前的transaction, 会话结束时不再更新workitem
+func (s *session) handOff() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	uid := s.transactionUID
+	s.transactionUID = ""
+	return uid
+}
+
+// touch 任意方向收到帧时刷新活动时间
+func (s *session) touch() {
+	s.mu.Lock()
+	s.lastActivity = time.Now()
+	s.mu.Unlock()
+}
+
+// expiry 会话超时的原因, 未超时返回空
+func (s *session) expiry(now time.Time) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.state != stateReady && s.state != stateStreaming {
+		return ""
+	}
+	if max := maxLifetime(); max > 0 && now.Sub(s.createdAt) > max {
+		return fmt.Sprintf("max lifetime %v exceeded", max)
+	}
+	since := s.history[len(s.history)-1].at
+	if s.state == stateReady && now.Sub(since) > connectGrace() {
+		return fmt.Sprintf("no stream connected within %v", connectGrace())
+	}
+	if s.state == stateStreaming && now.Sub(s.lastActivity) > idleTimeout() {
+		return fmt.Sprintf("idle for more than %v", idleTimeout())
+	}
+	return ""
+}
+
+// connectGrace ready的会话等待stream连接的时间
+func connectGrace() time.Duration {
+	if t := conf.GetInt("task.cars.render.session.connectGrace"); t > 0 {
+		return time.Second * time.Duration(t)
+	}
+	return time.Second * defaultConnectGrace
+}
+
+// idleTimeout 双向都没有帧时会话的最长空闲时间
+func idleTimeout() time.Duration {
+	if t := conf.GetInt("task.cars.render.session.idleTimeout"); t > 0 {
+		return time.Second * time.Duration(t)
+	}
+	return time.Second * defaultIdleTimeout
+}
+
+// maxLifetime 会话的最长存活时间, 配置为负数时不限制
+func maxLifetime() time.Duration {
+	t := conf.GetInt("task.cars.render.session.maxLifetime")
+	if t < 0 {
+		return 0
+	}
+	if t > 0 {
+		return time.Second * time.Duration(t)
+	}
+	return time.Second * defaultMaxLifetime
+}
+
+// attach Stream连接时调用, ready的会话进入streaming
+func (s *session) attach() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch s.state {
+	case stateReady:
+		err := s.transitLocked(stateStreaming)
+		if err != nil {
+			return err
+		}
+	case stateStreaming:
+	default:
+		return fmt.Errorf("session(%s) is %s", s.id, s.state)
+	}
+	s.streams++
+	s.lastActivity = time.Now()
+	return nil
+}
+
+// detach Stream断开时调用, 最后一个stream断开后回到ready
+func (s *session) detach() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.streams--
+	s.lastActivity = time.Now()
+	if s.streams == 0 && s.state == stateStreaming {
+		s.transitLocked(stateReady)
+	}
+}
+
+// info 会话的快照, ports和sids由调用方按stream填充
+func (s *session) info() *pb.SessionInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &pb.SessionInfo{
+		Id:           s.id,
+		WorkItemID:   s.workItemID,
+		Domain:       s.domain,
+		Layout:       s.layout,
+		State:        s.state,
+		CreatedAt:    s.createdAt.Unix(),
+		LastActivity: s.lastActivity.Unix(),
+		Streams:      int32(s.streams),
+		Transactions: append([]string(nil), s.transactions...),
+		Stats:        s.stats.snapshot(),
+	}
+}
@@ -0,0 +1,119 @@
+package carsrender
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	merrors "github.com/micro/go-micro/v2/errors"
+	pb "keyayun.com/seal-micro-runner/pkg/proto"
+)
+
+const (
+	defaultDrainTimeout = 30
+	drainPollInterval   = time.Millisecond * 500
+	shutdownReason      = "server shutting down"
+)
+
+// drainTimeout 关闭前等待stream断开的最长时间
+func drainTimeout() time.Duration {
+	if t := conf.GetInt("task.cars.render.drainTimeout"); t > 0 {
+		return time.Second * time.Duration(t)
+	}
+	return time.Second * defaultDrainTimeout
+}
+
+func (c *carsRenderService) isDraining() bool {
+	return atomic.LoadInt32(&c.draining) == 1
+}
+
+func errShuttingDown() error {
+	return merrors.New("carsRender.shuttingDown", shutdownReason, http.StatusServiceUnavailable)
+}
+
+// RegisterCheck drain期间让server的定期注册失败, 注销后不会再被注册回registry
+func (c *carsRenderService) RegisterCheck(context.Context) error {
+	if c.isDraining() {
+		return errShuttingDown()
+	}
+	return nil
+}
+
+// Shutdown 停止接受Start并从registry注销, 通知stream并等待drain, 然后关闭backend, 归还端口, 记录会话的最终状态
+func (c *carsRenderService) Shutdown(deregister func() error) {
+	// 在mu下设置, 之后的Start都会被拒绝, 之前通过检查的Start计入starting
+	c.mu.Lock()
+	atomic.StoreInt32(&c.draining, 1)
+	c.mu.Unlock()
+	err := deregister()
+	if err != nil {
+		log.Errorf("carsRenderService deregister failed: %v", err)
+	}
+	// 还在准备或排队的会话直接中断, 对应的Start会尽快失败返回
+	for _, s := range c.sessions() {
+		if state := s.info().State; state == statePending || state == statePreparing {
+			c.stopSession(s, stateInterrupted, shutdownReason)
+		}
+	}
+	deadline := time.Now().Add(drainTimeout())
+	for c.startsInFlight() > 0 && time.Now().Before(deadline) {
+		time.Sleep(drainPollInterval)
+	}
+	if n := c.startsInFlight(); n > 0 {
+		log.Warnf("carsRenderService shutdown: %d Starts still running after %v", n, drainTimeout())
+	}
+	log.Infof("carsRenderService shutdown: draining %d sessions", len(c.sessions()))
+
+	var bridges []*bridge
+	c.mu.Lock()
+	for _, w := range c.workers {
+		if w.bridge != nil {
+			bridges = append(bridges, w.bridge)
+		}
+	}
+	c.mu.Unlock()
+	for _, b := range bridges {
+		b.notify(&pb.StreamData{MessageType: websocket.CloseMessage, CloseCode: websocket.CloseServiceRestart, CloseText: shutdownReason})
+	}
+	for c.streaming() > 0 && time.Now().Before(deadline) {
+		time.Sleep(drainPollInterval)
+	}
+	if n := c.streaming(); n > 0 {
+		log.Warnf("carsRenderService shutdown: %d streams still attached after %v", n, drainTimeout())
+	}
+
+	c.mu.Lock()
+	workers := make([]*renderWorker, 0, len(c.workers))
+	for id, w := range c.workers {
+		workers = append(workers, w)
+		delete(c.workers, id)
+	}
+	c.mu.Unlock()
+	for _, w := range workers {
+		c.releaseWorker(w)
+	}
+
+	// drain之后重新取快照, 包括drain期间完成的Start创建的会话
+	for _, s := range c.sessions() {
+		c.stopSession(s, stateInterrupted, shutdownReason)
+		info := s.info()
+		log.Infof("carsRenderService shutdown: session(%s) workItemID(%s) %s", info.Id, info.WorkItemID, info.State)
+	}
+}
+
+func (c *carsRenderService) startsInFlight() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.starting
+}
+
+// streaming 所有会话上连接的stream数
+func (c *carsRenderService) streaming() int {
+	n := 0
+	for _, s := range c.sessions() {
+		n += int(s.info().Streams)
+	}
+	return n
+}
@@ -0,0 +1,87 @@
+package carsrender
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	merrors "github.com/micro/go-micro/v2/errors"
+)
+
+const defaultURLTTL = 14400
+
+// 签名中包含endpoint, stream url不能当作stop url使用, 反之亦然
+const (
+	endpointStream = "Services.Stream"
+	endpointStop   = "Services.Stop"
+)
+
+// streamPurpose observer的stream url单独签名, 不能以controller身份连接
+func streamPurpose(role streamRole) string {
+	if role == roleObserver {
+		return endpointStream + "/" + roleObserver
+	}
+	return endpointStream
+}
+
+var (
+	signingKey     []byte
+	signingKeyOnce sync.Once
+)
+
+// urlSigningKey 未配置key时使用进程内随机key, url只会路由回签发它的节点
+func urlSigningKey() []byte {
+	signingKeyOnce.Do(func() {
+		if key := conf.GetString("task.cars.render.urlSigning.key"); key != "" {
+			signingKey = []byte(key)
+			return
+		}
+		signingKey = make([]byte, 32)
+		_, err := rand.Read(signingKey)
+		if err != nil {
+			panic(fmt.Errorf("Fatal error url signing key: %s", err))
+		}
+		log.Warn("task.cars.render.urlSigning.key is not set, stream and stop urls are signed with a random key")
+	})
+	return signingKey
+}
+
+// urlTTL stream和stop url的有效期
+func urlTTL() time.Duration {
+	if t := conf.GetInt("task.cars.render.urlSigning.ttl"); t > 0 {
+		return time.Second * time.Duration(t)
+	}
+	return time.Second * defaultURLTTL
+}
+
+// signURL HMAC-SHA256(serverID, purpose, sid, domain, expires), purpose为endpoint, stream还区分角色
+func signURL(serverID, purpose, sid, domain string, expires int64) string {
+	mac := hmac.New(sha256.New, urlSigningKey())
+	mac.Write([]byte(serverID + "\n" + purpose + "\n" + sid + "\n" + domain + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyURL 拒绝缺少签名, 签名错误或已过期的url
+func verifyURL(serverID, purpose, sid, domain string, expires int64, sig string) error {
+	const id = "carsRender.badSignature"
+	if sig == "" || expires == 0 {
+		return merrors.Forbidden(id, "url of stream(%s) is not signed", sid)
+	}
+	expected, err := hex.DecodeString(signURL(serverID, purpose, sid, domain, expires))
+	if err != nil {
+		return err
+	}
+	actual, err := hex.DecodeString(sig)
+	if err != nil || !hmac.Equal(expected, actual) {
+		return merrors.Forbidden(id, "url of stream(%s) has a bad signature", sid)
+	}
+	if time.Now().Unix() > expires {
+		return merrors.Forbidden(id, "url of stream(%s) expired at %s", sid, time.Unix(expires, 0).Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package carsrender
+
+import (
+	"testing"
+	"time"
+
+	merrors "github.com/micro/go-micro/v2/errors"
+)
+
+func TestVerifyURL(t *testing.T) {
+	expires := time.Now().Add(time.Minute).Unix()
+	sig := signURL("server", endpointStream, "sid", "example.com", expires)
+	if err := verifyURL("server", endpointStream, "sid", "example.com", expires, sig); err != nil {
+		t.Fatalf("verifyURL of a valid url: %v", err)
+	}
+}
+
+func TestVerifyURLRejects(t *testing.T) {
+	expires := time.Now().Add(time.Minute).Unix()
+	expired := time.Now().Add(-time.Minute).Unix()
+	sig := signURL("server", endpointStream, "sid", "example.com", expires)
+	cases := []struct {
+		name                           string
+		serverID, purpose, sid, domain string
+		expires                        int64
+		sig                            string
+	}{
+		{"unsigned", "server", endpointStream, "sid", "example.com", expires, ""},
+		{"no expiry", "server", endpointStream, "sid", "example.com", 0, sig},
+		{"not hex", "server", endpointStream, "sid", "example.com", expires, "zz"},
+		{"other endpoint", "server", endpointStop, "sid", "example.com", expires, sig},
+		{"observer as controller", "server", streamPurpose(roleController), "sid", "example.com", expires, signURL("server", streamPurpose(roleObserver), "sid", "example.com", expires)},
+		{"controller as observer", "server", streamPurpose(roleObserver), "sid", "example.com", expires, sig},
+		{"other server", "other", endpointStream, "sid", "example.com", expires, sig},
+		{"other sid", "server", endpointStream, "other", "example.com", expires, sig},
+		{"other domain", "server", endpointStream, "sid", "other.com", expires, sig},
+		{"extended expiry", "server", endpointStream, "sid", "example.com", expires + 1, sig},
+		{"expired", "server", endpointStream, "sid", "example.com", expired, signURL("server", endpointStream, "sid", "example.com", expired)},
+	}
+	for _, c := range cases {
+		err := verifyURL(c.serverID, c.purpose, c.sid, c.domain, c.expires, c.sig)
+		if err == nil {
+			t.Errorf("%s: verifyURL accepted the url", c.name)
+			continue
+		}
+		if merr, ok := err.(*merrors.Error); !ok || merr.Code != 403 {
+			t.Errorf("%s: verifyURL = %v, want a 403", c.name, err)
+		}
+	}
+}
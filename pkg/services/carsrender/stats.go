@@ -0,0 +1,139 @@
+package carsrender
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	pb "keyayun.com/seal-micro-runner/pkg/proto"
+)
+
+// sessionStats 会话的流量和延迟统计, backend往返延迟按viewport记录在rttStats中
+type sessionStats struct {
+	mu              sync.Mutex
+	framesToClient  int64
+	bytesToClient   int64
+	framesToBackend int64
+	bytesToBackend  int64
+	connects        int64
+	connectTotal    time.Duration
+	attachedAt      time.Time
+	firstFrame      time.Duration
+}
+
+// backendConnected 记录一次backend websocket的连接耗时
+func (st *sessionStats) backendConnected(d time.Duration) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.connects++
+	st.connectTotal += d
+}
+
+// attached 第一个stream连接的时间, 用于计算首帧时间
+func (st *sessionStats) attached() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.attachedAt.IsZero() {
+		st.attachedAt = time.Now()
+	}
+}
+
+// fromBackend backend发给客户端的数据帧
+func (st *sessionStats) fromBackend(n int) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.framesToClient++
+	st.bytesToClient += int64(n)
+	if st.firstFrame == 0 && !st.attachedAt.IsZero() {
+		st.firstFrame = time.Since(st.attachedAt)
+	}
+}
+
+// toBackend 写入backend的输入帧
+func (st *sessionStats) toBackend(n int) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.framesToBackend++
+	st.bytesToBackend += int64(n)
+}
+
+func (st *sessionStats) snapshot() *pb.SessionStats {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	stats := &pb.SessionStats{
+		FramesToClient:  st.framesToClient,
+		BytesToClient:   st.bytesToClient,
+		FramesToBackend: st.framesToBackend,
+		BytesToBackend:  st.bytesToBackend,
+		FirstFrameMs:    st.firstFrame.Milliseconds(),
+	}
+	if st.connects > 0 {
+		stats.BackendConnectMs = (st.connectTotal / time.Duration(st.connects)).Milliseconds()
+	}
+	return stats
+}
+
+// summary 会话结束时输出的统计
+func (st *sessionStats) summary() string {
+	s := st.snapshot()
+	return fmt.Sprintf("to client %d frames/%d bytes, to backend %d frames/%d bytes, backend connect %dms, first frame %dms",
+		s.FramesToClient, s.BytesToClient, s.FramesToBackend, s.BytesToBackend, s.BackendConnectMs, s.FirstFrameMs)
+}
+
+// rttStats 一个viewport到backend的往返延迟, 由runner的心跳ping和backend的pong测量
+// 属于renderWorker, 重连后的bridge继续累计
+type rttStats struct {
+	mu      sync.Mutex
+	pingAt  time.Time
+	samples int64
+	total   time.Duration
+	max     time.Duration
+	last    time.Duration
+}
+
+// ping 发出心跳ping, 未收到pong的ping被新的ping覆盖
+func (r *rttStats) ping() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pingAt = time.Now()
+}
+
+// pong 收到心跳pong, 记录与最近一次ping之间的时间
+func (r *rttStats) pong() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pingAt.IsZero() {
+		return
+	}
+	rtt := time.Since(r.pingAt)
+	r.pingAt = time.Time{}
+	r.samples++
+	r.total += rtt
+	r.last = rtt
+	if rtt > r.max {
+		r.max = rtt
+	}
+}
+
+func (r *rttStats) snapshot(sid string, port int) *pb.ViewportStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := &pb.ViewportStats{
+		Sid:               sid,
+		Port:              int32(port),
+		BackendRttMaxMs:   r.max.Milliseconds(),
+		BackendRttLastMs:  r.last.Milliseconds(),
+		BackendRttSamples: r.samples,
+	}
+	if r.samples > 0 {
+		stats.BackendRttAvgMs = (r.total / time.Duration(r.samples)).Milliseconds()
+	}
+	return stats
+}
+
+// summary stream结束时输出的统计
+func (r *rttStats) summary() string {
+	s := r.snapshot("", 0)
+	return fmt.Sprintf("backend rtt avg %dms max %dms last %dms (%d samples)",
+		s.BackendRttAvgMs, s.BackendRttMaxMs, s.BackendRttLastMs, s.BackendRttSamples)
+}
@@ -0,0 +1,29 @@
+package carsrender
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRTTStats(t *testing.T) {
+	r := &rttStats{}
+	r.pong()
+	if s := r.snapshot("sid", 9001); s.BackendRttSamples != 0 {
+		t.Fatalf("pong without ping counted: %v", s)
+	}
+	r.ping()
+	time.Sleep(20 * time.Millisecond)
+	r.pong()
+	// 重复的pong只对应一次ping
+	r.pong()
+	s := r.snapshot("sid", 9001)
+	if s.BackendRttSamples != 1 {
+		t.Fatalf("samples = %d, want 1", s.BackendRttSamples)
+	}
+	if s.BackendRttLastMs < 20 || s.BackendRttAvgMs != s.BackendRttLastMs || s.BackendRttMaxMs != s.BackendRttLastMs {
+		t.Fatalf("rtt = %v, want one sample of at least 20ms", s)
+	}
+	if s.Sid != "sid" || s.Port != 9001 {
+		t.Fatalf("viewport = %s/%d, want sid/9001", s.Sid, s.Port)
+	}
+}
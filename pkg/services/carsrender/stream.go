@@ -0,0 +1,579 @@
+package carsrender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	merrors "github.com/micro/go-micro/v2/errors"
+	pb "keyayun.com/seal-micro-runner/pkg/proto"
+)
+
+const (
+	controlWriteWait    = time.Second * 5
+	defaultResumeWindow = 30
+	defaultReplayFrames = 64
+)
+
+type streamRole = string
+
+const (
+	roleController streamRole = "controller"
+	roleObserver   streamRole = "observer"
+)
+
+var (
+	errStreamReplaced = errors.New("stream resumed by another connection")
+	errBridgeClosed   = errors.New("backend connection closed")
+	errObserverInput  = errors.New("observers are read-only")
+)
+
+// bridge 一个_sid对应的backend连接, 保留消息类型和控制帧
+// 客户端断开后连接在resumeWindow内保持, 同一_sid重新连接时回放错过的帧
+// controller之外可以有任意个只读的observer, backend的每一帧都会发给它们
+type bridge struct {
+	s         *session
+	sid       string
+	ws        *websocket.Conn
+	toBackend *frameQueue
+
+	mu        sync.Mutex
+	client    *streamClient
+	observers map[*streamClient]bool
+	ring      *frameRing
+	seq       int64
+	timer     *time.Timer
+	closed    bool
+	closeSent bool
+	dropped   int64
+	done      chan struct{}
+	// lost backend心跳超时等连接不可用时调用
+	lost func(reason string)
+	// rtt 心跳ping/pong测量的backend往返延迟
+	rtt *rttStats
+
+	// sent 已发送给客户端的最后一帧序号
+	sent int64
+}
+
+// streamClient 连接到bridge的一次Stream调用
+type streamClient struct {
+	role    streamRole
+	stream  pb.Services_StreamStream
+	sendMu  sync.Mutex
+	queue   *frameQueue
+	closing bool
+	done    chan struct{}
+	// lastSeen 最后一次收到客户端帧的时间, UnixNano
+	lastSeen int64
+}
+
+// resumeWindow 客户端断开后backend连接保留的时间
+func resumeWindow() time.Duration {
+	if t := conf.GetInt("task.cars.render.stream.resumeWindow"); t > 0 {
+		return time.Second * time.Duration(t)
+	}
+	return time.Second * defaultResumeWindow
+}
+
+// replayFrames 为重连保留的最近帧数
+func replayFrames() int {
+	if n := conf.GetInt("task.cars.render.stream.replayFrames"); n > 0 {
+		return n
+	}
+	return defaultReplayFrames
+}
+
+func newBridge(s *session, sid string, ws *websocket.Conn, rtt *rttStats) *bridge {
+	return &bridge{
+		s:         s,
+		sid:       sid,
+		ws:        ws,
+		rtt:       rtt,
+		toBackend: newFrameQueue(backendQueueSize(), dropNone),
+		observers: make(map[*streamClient]bool),
+		ring:      newFrameRing(replayFrames()),
+		done:      make(chan struct{}),
+	}
+}
+
+func newStreamClient(role streamRole, stream pb.Services_StreamStream, queueSize int) *streamClient {
+	return &streamClient{
+		role:     role,
+		stream:   stream,
+		queue:    newFrameQueue(queueSize, clientDropPolicy()),
+		done:     make(chan struct{}),
+		lastSeen: time.Now().UnixNano(),
+	}
+}
+
+// Stream 桥接客户端stream和backend的/ws, 会话被关闭时把原因返回给客户端
+func (c *carsRenderService) Stream(ctx context.Context, stream pb.Services_StreamStream) error {
+	data, err := stream.Recv()
+	if err != nil {
+		log.Errorf("carsRenderService Stream.Recv failed: %v", err)
+		return err
+	}
+	worker, err := c.getPreParams(data.XSid)
+	if err != nil {
+		log.Errorf("carsRenderService Stream getParam: %v", err)
+		return err
+	}
+	s := worker.session
+	role := data.Role
+	if role == "" {
+		role = roleController
+	}
+	if role != roleController && role != roleObserver {
+		log.Errorf("carsRenderService Stream failed: unknown role %q", role)
+		return merrors.BadRequest("carsRender.badRole", "unknown stream role %q", role)
+	}
+	// 角色包含在签名中, observer的url不能接管controller
+	err = verifyURL(c.serverID, streamPurpose(role), data.XSid, s.domain, data.XExp, data.XSig)
+	if err != nil {
+		log.Errorf("carsRenderService Stream failed: %v", err)
+		return err
+	}
+	err = s.attach()
+	if err != nil {
+		log.Errorf("carsRenderService Stream attach failed: %v", err)
+		return err
+	}
+	defer s.detach()
+
+	b, err := c.bridgeOf(data.XSid, worker)
+	if err != nil {
+		log.Errorf("carsRenderService Stream connect backend failed: %v", err)
+		return err
+	}
+	var client *streamClient
+	if role == roleObserver {
+		client, err = b.observe(stream)
+	} else {
+		client, err = b.attach(stream)
+	}
+	if err != nil {
+		log.Errorf("carsRenderService Stream attach backend failed: %v", err)
+		return err
+	}
+	defer b.detach(client)
+	s.stats.attached()
+	err = client.send(&pb.StreamData{XSid: data.XSid, Role: client.role})
+	if err != nil {
+		log.Errorf("carsRenderService Stream send role failed: %v", err)
+		return err
+	}
+
+	errCh := make(chan error, 3)
+	go func() {
+		errCh <- b.readClient(client)
+	}()
+	go func() {
+		errCh <- b.writeClient(client)
+	}()
+	go func() {
+		errCh <- b.heartbeatClient(client)
+	}()
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-stream.Context().Done():
+		err = stream.Context().Err()
+	case <-s.ctx.Done():
+	}
+	if reason := s.closeReason(); reason != "" {
+		client.send(&pb.StreamData{MessageType: websocket.CloseMessage, CloseCode: websocket.CloseGoingAway, CloseText: reason})
+		return fmt.Errorf("session(%s) closed: %s", s.id, reason)
+	}
+	return err
+}
+
+// bridgeOf 复用worker上仍在保持的backend连接, 否则重新连接backend
+func (c *carsRenderService) bridgeOf(id string, worker *renderWorker) (*bridge, error) {
+	c.mu.Lock()
+	b := worker.bridge
+	c.mu.Unlock()
+	if b != nil && !b.isClosed() {
+		return b, nil
+	}
+	dialStart := time.Now()
+	ws, _, err := websocket.DefaultDialer.Dial(backendURL(worker.port), nil)
+	if err != nil {
+		return nil, err
+	}
+	worker.session.stats.backendConnected(time.Since(dialStart))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.workers[id] != worker {
+		ws.Close()
+		return nil, os.ErrNotExist
+	}
+	if worker.bridge != nil && !worker.bridge.isClosed() {
+		ws.Close()
+		return worker.bridge, nil
+	}
+	b = newBridge(worker.session, id, ws, worker.rtt)
+	b.lost = func(reason string) {
+		c.stopSession(worker.session, stateFailed, reason)
+	}
+	worker.bridge = b
+	go b.readBackend()
+	go b.writeBackend()
+	go b.pingBackend()
+	return b, nil
+}
+
+// attach 绑定客户端, 已有客户端时由新的连接接管, 并回放其未收到的帧
+func (b *bridge) attach(stream pb.Services_StreamStream) (*streamClient, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, errBridgeClosed
+	}
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	replay := b.ring.since(atomic.LoadInt64(&b.sent))
+	size := clientQueueSize()
+	if len(replay) > size {
+		size = len(replay)
+	}
+	client := newStreamClient(roleController, stream, size)
+	for _, f := range replay {
+		client.queue.push(f)
+	}
+	if old := b.client; old != nil {
+		log.Infof("session(%s) stream taken over by a new connection", b.s.id)
+		b.dropClient(old)
+		old.queue.close(errStreamReplaced)
+	} else if len(replay) > 0 {
+		log.Infof("session(%s) stream resumed, replaying %d frames", b.s.id, len(replay))
+	}
+	b.client = client
+	return client, nil
+}
+
+// observe 以只读的observer绑定客户端, 从下一帧开始接收
+func (b *bridge) observe(stream pb.Services_StreamStream) (*streamClient, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, errBridgeClosed
+	}
+	client := newStreamClient(roleObserver, stream, clientQueueSize())
+	b.observers[client] = true
+	log.Infof("session(%s) observer attached, %d observers", b.s.id, len(b.observers))
+	return client, nil
+}
+
+// detach 客户端断开, controller非正常关闭或最后一个observer离开时保留backend连接等待重连
+func (b *bridge) detach(client *streamClient) {
+	b.mu.Lock()
+	defer close(client.done)
+	if b.observers[client] {
+		delete(b.observers, client)
+		b.dropClient(client)
+		if !b.closed && b.idleLocked() {
+			log.Infof("session(%s) last observer detached, keeping backend for %v", b.s.id, resumeWindow())
+			b.expireLater()
+		}
+		b.mu.Unlock()
+		client.queue.close(nil)
+		return
+	}
+	if b.client != client {
+		b.mu.Unlock()
+		return
+	}
+	b.client = nil
+	b.dropClient(client)
+	if !b.closed && !client.closing {
+		log.Infof("session(%s) stream detached, keeping backend for %v", b.s.id, resumeWindow())
+		b.expireLater()
+	}
+	b.mu.Unlock()
+	client.queue.close(nil)
+}
+
+// idleLocked 没有controller也没有observer, 调用方需持有b.mu
+func (b *bridge) idleLocked() bool {
+	return b.client == nil && len(b.observers) == 0
+}
+
+// expireLater resumeWindow后仍没有客户端时关闭backend连接, 调用方需持有b.mu
+func (b *bridge) expireLater() {
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+	b.timer = time.AfterFunc(resumeWindow(), b.expire)
+}
+
+// dropClient 累计客户端队列丢弃的帧数, 调用方需持有b.mu
+func (b *bridge) dropClient(client *streamClient) {
+	_, dropped := client.queue.stats()
+	b.dropped += dropped
+}
+
+// expire resumeWindow内没有重连时关闭backend连接, 仍有observer时保留
+func (b *bridge) expire() {
+	b.mu.Lock()
+	idle := b.idleLocked()
+	b.mu.Unlock()
+	if idle {
+		log.Infof("session(%s) stream not resumed within %v", b.s.id, resumeWindow())
+		b.close(nil)
+	}
+}
+
+func (b *bridge) isClosed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.closed
+}
+
+// deliver 发给controller和所有observer, 数据帧同时记录到ring供重连时回放
+func (b *bridge) deliver(data *pb.StreamData, replay bool) {
+	b.s.recorder.record(b.sid, dirToClient, data)
+	f := &frame{data: data}
+	b.mu.Lock()
+	if replay {
+		b.seq++
+		f.seq = b.seq
+		b.ring.put(f)
+	}
+	clients := b.clientsLocked()
+	b.mu.Unlock()
+	for _, client := range clients {
+		client.queue.push(f)
+	}
+}
+
+// notify 发给所有客户端, 不记录到ring
+func (b *bridge) notify(data *pb.StreamData) {
+	b.mu.Lock()
+	clients := b.clientsLocked()
+	b.mu.Unlock()
+	for _, client := range clients {
+		client.queue.push(&frame{data: data})
+	}
+}
+
+// clientsLocked controller和所有observer, 调用方需持有b.mu
+func (b *bridge) clientsLocked() []*streamClient {
+	clients := make([]*streamClient, 0, len(b.observers)+1)
+	if b.client != nil {
+		clients = append(clients, b.client)
+	}
+	for o := range b.observers {
+		clients = append(clients, o)
+	}
+	return clients
+}
+
+// readBackend backend -> 客户端, ping/pong和close也转发给客户端, 心跳的pong除外
+func (b *bridge) readBackend() {
+	// backend的ping由runner直接回复pong, 转发给客户端的ping仅供参考, 客户端不需要回复
+	b.ws.SetPingHandler(func(appData string) error {
+		b.extendReadDeadline()
+		err := b.ws.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(controlWriteWait))
+		if err != nil && err != websocket.ErrCloseSent {
+			log.Errorf("carsRenderService Stream pong to backend failed: %v", err)
+		}
+		b.deliver(&pb.StreamData{MessageType: websocket.PingMessage, Data: []byte(appData)}, false)
+		return nil
+	})
+	b.ws.SetPongHandler(func(appData string) error {
+		b.extendReadDeadline()
+		if appData == heartbeatPayload {
+			b.rtt.pong()
+			return nil
+		}
+		b.deliver(&pb.StreamData{MessageType: websocket.PongMessage, Data: []byte(appData)}, false)
+		return nil
+	})
+	b.extendReadDeadline()
+	for {
+		messageType, message, err := b.ws.ReadMessage()
+		if err != nil {
+			if ce, ok := err.(*websocket.CloseError); ok {
+				log.Infof("carsRenderService Stream backend closed: %d %s", ce.Code, ce.Text)
+				b.deliver(&pb.StreamData{MessageType: websocket.CloseMessage, CloseCode: int32(ce.Code), CloseText: ce.Text}, false)
+				b.close(nil)
+				return
+			}
+			log.Errorf("carsRenderService Stream ws recv failed: %v", err)
+			if isTimeout(err) {
+				b.lose(fmt.Sprintf("backend sent nothing for %v", backendPongTimeout()))
+				return
+			}
+			b.close(err)
+			return
+		}
+		b.extendReadDeadline()
+		b.s.touch()
+		b.s.stats.fromBackend(len(message))
+		log.Debugf("Stream ws recv: type(%d) %d bytes", messageType, len(message))
+		b.deliver(&pb.StreamData{MessageType: int32(messageType), Data: message}, true)
+	}
+}
+
+// writeBackend 客户端输入 -> backend
+func (b *bridge) writeBackend() {
+	for {
+		f, err := b.toBackend.pop()
+		if err != nil {
+			return
+		}
+		if f.data.MessageType == websocket.CloseMessage {
+			log.Infof("carsRenderService Stream client closed: %d %s", f.data.CloseCode, f.data.CloseText)
+			b.mu.Lock()
+			b.closeSent = true
+			b.mu.Unlock()
+			writeFrame(b.ws, f.data)
+			b.close(nil)
+			return
+		}
+		err = writeFrame(b.ws, f.data)
+		if err != nil {
+			log.Errorf("carsRenderService ws write failed: %v", err)
+			b.close(err)
+			return
+		}
+		if !isControlFrame(f) {
+			b.s.stats.toBackend(len(f.data.Data))
+		}
+	}
+}
+
+// writeFrame 按messageType写入websocket, 未指定时按文本发送
+func writeFrame(ws *websocket.Conn, data *pb.StreamData) error {
+	switch data.MessageType {
+	case 0:
+		return ws.WriteMessage(websocket.TextMessage, data.Data)
+	case websocket.TextMessage, websocket.BinaryMessage:
+		return ws.WriteMessage(int(data.MessageType), data.Data)
+	case websocket.PingMessage, websocket.PongMessage:
+		return ws.WriteControl(int(data.MessageType), data.Data, time.Now().Add(controlWriteWait))
+	case websocket.CloseMessage:
+		code := int(data.CloseCode)
+		if code == 0 {
+			code = websocket.CloseNormalClosure
+		}
+		return ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, data.CloseText), time.Now().Add(controlWriteWait))
+	}
+	return fmt.Errorf("unsupported message type %d", data.MessageType)
+}
+
+// readClient 客户端 -> toBackend, 输入事件从不丢弃
+// observer发送输入时以policy violation关闭它的stream
+func (b *bridge) readClient(client *streamClient) error {
+	for {
+		data, err := client.stream.Recv()
+		if err != nil {
+			log.Errorf("carsRenderService stream recv failed: %v", err)
+			return err
+		}
+		atomic.StoreInt64(&client.lastSeen, time.Now().UnixNano())
+		// backend的ping已由runner回复, 客户端的pong不再转发
+		if data.Heartbeat || data.MessageType == websocket.PongMessage {
+			continue
+		}
+		b.s.touch()
+		log.Debugf("carsRenderService stream.recv type(%d) %d bytes", data.MessageType, len(data.Data))
+		closing := data.MessageType == websocket.CloseMessage
+		if client.role == roleObserver {
+			if closing {
+				return nil
+			}
+			if data.MessageType == websocket.PingMessage {
+				continue
+			}
+			log.Warnf("session(%s) input from observer rejected", b.s.id)
+			client.send(&pb.StreamData{MessageType: websocket.CloseMessage, CloseCode: websocket.ClosePolicyViolation, CloseText: errObserverInput.Error()})
+			return merrors.Forbidden("carsRender.observerInput", errObserverInput.Error())
+		}
+		if closing {
+			b.mu.Lock()
+			client.closing = true
+			b.mu.Unlock()
+		}
+		b.s.recorder.record(b.sid, dirToBackend, data)
+		err = b.toBackend.push(&frame{data: data})
+		if err != nil || closing {
+			return err
+		}
+	}
+}
+
+// writeClient 客户端队列 -> 客户端
+func (b *bridge) writeClient(client *streamClient) error {
+	for {
+		f, err := client.queue.pop()
+		if err != nil {
+			return client.queue.err()
+		}
+		err = client.send(f.data)
+		if err != nil {
+			log.Errorf("carsRenderService stream write failed: %v", err)
+			return err
+		}
+		if f.seq > 0 && client.role == roleController {
+			atomic.StoreInt64(&b.sent, f.seq)
+		}
+	}
+}
+
+func (client *streamClient) send(data *pb.StreamData) error {
+	client.sendMu.Lock()
+	defer client.sendMu.Unlock()
+	return client.stream.Send(data)
+}
+
+// close 关闭backend连接, 当前客户端的队列发送完后结束
+func (b *bridge) close(cause error) {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	close(b.done)
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	clients := b.clientsLocked()
+	closeSent := b.closeSent
+	b.mu.Unlock()
+	for _, client := range clients {
+		client.queue.close(cause)
+	}
+	b.toBackend.close(cause)
+	if !closeSent {
+		b.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(controlWriteWait))
+	}
+	b.ws.Close()
+}
+
+// stats 两个方向的队列深度, 累计丢弃的帧数和observer数
+func (b *bridge) stats() (toClient, toBackend int, dropped int64, observers int) {
+	b.mu.Lock()
+	clients := b.clientsLocked()
+	dropped = b.dropped
+	observers = len(b.observers)
+	b.mu.Unlock()
+	for _, client := range clients {
+		n, d := client.queue.stats()
+		toClient += n
+		dropped += d
+	}
+	toBackend, d := b.toBackend.stats()
+	return toClient, toBackend, dropped + d, observers
+}
@@ -0,0 +1,116 @@
+package carsrender
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	merrors "github.com/micro/go-micro/v2/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// UPS的procedure step状态
+const (
+	upsInProgress = "IN PROGRESS"
+	upsCompleted  = "COMPLETED"
+	upsCanceled   = "CANCELED"
+)
+
+func workItemSyncEnabled() bool {
+	return conf.GetBool("task.cars.render.workItemSync")
+}
+
+// newTransactionUID 由UUID派生的UID: 2.25.<UUID的十进制>
+func newTransactionUID() string {
+	u := uuid.NewV4()
+	return "2.25." + new(big.Int).SetBytes(u[:]).String()
+}
+
+// claimWorkItem 会话ready后以新的transaction UID把workitem置为IN PROGRESS
+// 接管了被替换会话的transaction时只更新进度, workitem已是final状态时返回错误
+func (c *carsRenderService) claimWorkItem(s *session) error {
+	if !workItemSyncEnabled() {
+		return nil
+	}
+	client, err := s.sealClient()
+	if err != nil {
+		log.Errorf("carsRenderService claimWorkItem(%s) failed: %v", s.workItemID, err)
+		return nil
+	}
+	uid := s.transaction()
+	if uid == "" {
+		workItem, err := client.GetWorkItemByID(s.workItemID)
+		if err != nil {
+			log.Errorf("carsRenderService claimWorkItem(%s) failed when get workitem: %v", s.workItemID, err)
+		}
+		// COMPLETED和CANCELED不能再回到IN PROGRESS
+		if state := dicomString(workItem, tagProcedureStepState); state == upsCompleted || state == upsCanceled {
+			log.Errorf("carsRenderService claimWorkItem(%s) failed: workitem is %s", s.workItemID, state)
+			return merrors.Conflict("carsRender.workItemFinal", "workitem(%s) is %s", s.workItemID, state)
+		}
+		uid = newTransactionUID()
+		err = client.UpdateWorkItemState(s.workItemID, map[string]interface{}{
+			tagProcedureStepState: dicomAttr("CS", upsInProgress),
+			tagTransactionUID:     dicomAttr("UI", uid),
+		})
+		if err != nil {
+			log.Errorf("carsRenderService claimWorkItem(%s) failed: %v", s.workItemID, err)
+			return nil
+		}
+		s.setTransaction(uid)
+	}
+	err = client.UpdateWorkItem(s.workItemID, map[string]interface{}{
+		tagTransactionUID: dicomAttr("UI", uid),
+		tagProgressInformation: dicomAttr("SQ", map[string]interface{}{
+			tagProcedureStepProgress: dicomAttr("DS", 0),
+			tagProgressDescription:   dicomAttr("ST", fmt.Sprintf("rendering on %s", c.serverID)),
+		}),
+	})
+	if err != nil {
+		log.Errorf("carsRenderService claimWorkItem(%s) failed when update progress: %v", s.workItemID, err)
+	}
+	log.Infof("session(%s) workItemID(%s): %s, transactionUID(%s)", s.id, s.workItemID, upsInProgress, uid)
+	return nil
+}
+
+// releaseWorkItem 会话结束时正常停止的workitem置为COMPLETED, 过期, 失败或被关闭中断的置为CANCELED
+// 未被claim的workitem保持SCHEDULED以便重试
+func (c *carsRenderService) releaseWorkItem(s *session, final sessionState, reason string) {
+	if !workItemSyncEnabled() {
+		return
+	}
+	uid := s.transaction()
+	if uid == "" {
+		log.Infof("session(%s) workItemID(%s): not claimed, left unchanged", s.id, s.workItemID)
+		return
+	}
+	client, err := s.sealClient()
+	if err != nil {
+		log.Errorf("carsRenderService releaseWorkItem(%s) failed: %v", s.workItemID, err)
+		return
+	}
+	state := upsCompleted
+	if final != stateStopped {
+		state = upsCanceled
+		err := client.UpdateWorkItem(s.workItemID, map[string]interface{}{
+			tagTransactionUID: dicomAttr("UI", uid),
+			tagProgressInformation: dicomAttr("SQ", map[string]interface{}{
+				tagReasonForCancellation: dicomAttr("LT", fmt.Sprintf("%s: %s", final, reason)),
+				tagCancellationDateTime:  dicomAttr("DT", time.Now().Format(dicomDateTimeLayout)),
+			}),
+		})
+		if err != nil {
+			log.Errorf("carsRenderService releaseWorkItem(%s) failed when update reason: %v", s.workItemID, err)
+		}
+	}
+	err = client.UpdateWorkItemState(s.workItemID, map[string]interface{}{
+		tagProcedureStepState: dicomAttr("CS", state),
+		tagTransactionUID:     dicomAttr("UI", uid),
+	})
+	if err != nil {
+		log.Errorf("carsRenderService releaseWorkItem(%s) failed: %v", s.workItemID, err)
+		return
+	}
+	s.setTransaction("")
+	log.Infof("session(%s) workItemID(%s): %s, transactionUID(%s)", s.id, s.workItemID, state, uid)
+}
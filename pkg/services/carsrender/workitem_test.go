@@ -0,0 +1,16 @@
+package carsrender
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewTransactionUID(t *testing.T) {
+	uid := newTransactionUID()
+	if !strings.HasPrefix(uid, "2.25.") {
+		t.Fatalf("uid = %s, want the 2.25 root", uid)
+	}
+	if err := checkUID(uid); err != nil {
+		t.Fatalf("uid is not a DICOM UID: %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package carsrender
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// dicomUID DICOM UID由点分隔的数字组成, 最长64个字符
+var dicomUID = regexp.MustCompile(`^[0-9]+(\.[0-9]+)*$`)
+
+// checkUID 远端返回的UID会作为目录和文件名, 拒绝非UID的值以免写出工作目录
+func checkUID(uid string) error {
+	if len(uid) > 64 || !dicomUID.MatchString(uid) {
+		return fmt.Errorf("%q is not a DICOM UID", uid)
+	}
+	return nil
+}
+
+// seriesRef 待下载的series
+type seriesRef struct {
+	studyUID  string
+	seriesUID string
+	sopUIDs   []string
+}
+
+// prepareWorkspace 根据workitem下载series到rootPath下的工作目录
+func (c *carsRenderService) prepareWorkspace(s *session) {
+	defer close(s.ready)
+	err := c.downloadWorkspace(s)
+	if err != nil {
+		log.Errorf("carsRenderService prepareWorkspace(workItemID: %s) failed: %v", s.workItemID, err)
+		os.RemoveAll(s.workspace)
+		s.err = err
+		return
+	}
+	log.Infof("carsRenderService prepareWorkspace(workItemID: %s) ready: %s", s.workItemID, s.workspace)
+}
+
+func (c *carsRenderService) downloadWorkspace(s *session) error {
+	client, err := s.sealClient()
+	if err != nil {
+		return err
+	}
+	workItem, err := client.GetWorkItemByID(s.workItemID)
+	if err != nil {
+		return fmt.Errorf("GetWorkItemByID failed: %v", err)
+	}
+	refs := referencedSeries(workItem)
+	if len(refs) == 0 {
+		return fmt.Errorf("workitem(%s) references no series", s.workItemID)
+	}
+	for _, ref := range refs {
+		select {
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		default:
+		}
+		err = downloadSeries(s, ref)
+		if err != nil {
+			return fmt.Errorf("download series(%s) failed: %v", ref.seriesUID, err)
+		}
+	}
+	return nil
+}
+
+func downloadSeries(s *session, ref *seriesRef) error {
+	for _, uid := range []string{ref.studyUID, ref.seriesUID} {
+		if err := checkUID(uid); err != nil {
+			return err
+		}
+	}
+	seriesPath := filepath.Join(s.workspace, ref.seriesUID)
+	err := os.MkdirAll(seriesPath, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	sopUIDs := ref.sopUIDs
+	if len(sopUIDs) == 0 {
+		client, err := s.sealClient()
+		if err != nil {
+			return err
+		}
+		instances, err := client.GetAllInstancesByDicomweb(ref.studyUID, ref.seriesUID)
+		if err != nil {
+			return err
+		}
+		for _, instance := range instances {
+			if uid := dicomString(instance, tagSOPInstanceUID); uid != "" {
+				sopUIDs = append(sopUIDs, uid)
+			}
+		}
+	}
+	if len(sopUIDs) == 0 {
+		return errors.New("series has no instance")
+	}
+	for _, sopUID := range sopUIDs {
+		select {
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		default:
+		}
+		if err := checkUID(sopUID); err != nil {
+			return err
+		}
+		filePath := filepath.Join(seriesPath, sopUID+".dcm")
+		client, err := s.sealClient()
+		if err != nil {
+			return err
+		}
+		err = client.DownloadFileByDicomweb(ref.studyUID, ref.seriesUID, sopUID, filePath)
+		if err != nil {
+			return err
+		}
+		// DownloadFileByDicomweb 对非multipart响应不会写文件
+		if _, err = os.Stat(filePath); err != nil {
+			return fmt.Errorf("instance(%s) is not on disk: %v", sopUID, err)
+		}
+	}
+	return nil
+}
+
+// referencedSeries 解析workitem的Input Information Sequence
+func referencedSeries(workItem map[string]interface{}) []*seriesRef {
+	var refs []*seriesRef
+	for _, item := range dicomSequence(workItem, tagInputInformationSequence) {
+		ref := &seriesRef{
+			studyUID:  dicomString(item, tagStudyInstanceUID),
+			seriesUID: dicomString(item, tagSeriesInstanceUID),
+		}
+		if ref.studyUID == "" || ref.seriesUID == "" {
+			continue
+		}
+		for _, sop := range dicomSequence(item, tagReferencedSOPSequence) {
+			if uid := dicomString(sop, tagReferencedSOPInstanceUID); uid != "" {
+				ref.sopUIDs = append(ref.sopUIDs, uid)
+			}
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
@@ -0,0 +1,321 @@
+package registry
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/micro/go-micro/v2/registry"
+)
+
+const (
+	defaultRegistryFile = "registry.json"
+	fileWatchInterval   = time.Second
+)
+
+// FileReg 把服务写入本地json文件, 同一台机器上的进程不需要consul也能互相发现
+type FileReg struct{}
+
+func newFileReg() Regs {
+	return &FileReg{}
+}
+
+func (f *FileReg) Name() string {
+	return "file"
+}
+
+func (f *FileReg) GetReg() registry.Registry {
+	path := conf.GetString("fileRegistry.path")
+	if path == "" {
+		path = filepath.Join(os.TempDir(), defaultRegistryFile)
+	}
+	return &fileRegistry{path: path}
+}
+
+// fileRecord 一个node的注册信息, TTL内没有重新注册的node会被忽略
+type fileRecord struct {
+	Service *registry.Service `json:"service"`
+	Expires int64             `json:"expires"`
+}
+
+type fileRegistry struct {
+	opts registry.Options
+	path string
+	mu   sync.Mutex
+}
+
+func (r *fileRegistry) Init(opts ...registry.Option) error {
+	for _, o := range opts {
+		o(&r.opts)
+	}
+	return nil
+}
+
+func (r *fileRegistry) Options() registry.Options {
+	return r.opts
+}
+
+// load 读取未过期的记录, 文件不存在时为空
+func (r *fileRegistry) load() ([]*fileRecord, error) {
+	b, err := ioutil.ReadFile(r.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var records []*fileRecord
+	if len(b) > 0 {
+		err = json.Unmarshal(b, &records)
+		if err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now().Unix()
+	alive := records[:0]
+	for _, rec := range records {
+		if rec.Expires == 0 || rec.Expires > now {
+			alive = append(alive, rec)
+		}
+	}
+	return alive, nil
+}
+
+// save 先写同目录下唯一的临时文件再rename, 其它进程不会读到一半的文件
+func (r *fileRegistry) save(records []*fileRecord) error {
+	b, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(r.path), filepath.Base(r.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(b)
+	if err == nil {
+		err = tmp.Chmod(0644)
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), r.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// lock 对<path>.lock加flock, 在共享文件的进程之间互斥
+func (r *fileRegistry) lock() (func(), error) {
+	f, err := os.OpenFile(r.path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
+
+// update 在进程内和进程间互斥地读取, 修改并写回, 读取不加锁, rename保证读到完整的文件
+func (r *fileRegistry) update(fn func([]*fileRecord) []*fileRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	records, err := r.load()
+	if err != nil {
+		return err
+	}
+	return r.save(fn(records))
+}
+
+func without(records []*fileRecord, s *registry.Service) []*fileRecord {
+	ids := make(map[string]bool)
+	for _, n := range s.Nodes {
+		ids[n.Id] = true
+	}
+	kept := records[:0]
+	for _, rec := range records {
+		if rec.Service.Name == s.Name && len(rec.Service.Nodes) > 0 && ids[rec.Service.Nodes[0].Id] {
+			continue
+		}
+		kept = append(kept, rec)
+	}
+	return kept
+}
+
+func (r *fileRegistry) Register(s *registry.Service, opts ...registry.RegisterOption) error {
+	var options registry.RegisterOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	var expires int64
+	if options.TTL > 0 {
+		expires = time.Now().Add(options.TTL).Unix()
+	}
+	return r.update(func(records []*fileRecord) []*fileRecord {
+		records = without(records, s)
+		for _, n := range s.Nodes {
+			svc := *s
+			svc.Nodes = []*registry.Node{n}
+			records = append(records, &fileRecord{Service: &svc, Expires: expires})
+		}
+		return records
+	})
+}
+
+func (r *fileRegistry) Deregister(s *registry.Service, opts ...registry.DeregisterOption) error {
+	return r.update(func(records []*fileRecord) []*fileRecord {
+		return without(records, s)
+	})
+}
+
+// services 按name和version合并node, name为空时返回全部
+func (r *fileRegistry) services(name string) (map[string]*registry.Service, error) {
+	r.mu.Lock()
+	records, err := r.load()
+	r.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	services := make(map[string]*registry.Service)
+	for _, rec := range records {
+		if name != "" && rec.Service.Name != name {
+			continue
+		}
+		key := rec.Service.Name + ":" + rec.Service.Version
+		svc, ok := services[key]
+		if !ok {
+			copied := *rec.Service
+			copied.Nodes = nil
+			svc = &copied
+			services[key] = svc
+		}
+		svc.Nodes = append(svc.Nodes, rec.Service.Nodes...)
+	}
+	return services, nil
+}
+
+func (r *fileRegistry) GetService(name string, opts ...registry.GetOption) ([]*registry.Service, error) {
+	services, err := r.services(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(services) == 0 {
+		return nil, registry.ErrNotFound
+	}
+	list := make([]*registry.Service, 0, len(services))
+	for _, svc := range services {
+		list = append(list, svc)
+	}
+	return list, nil
+}
+
+func (r *fileRegistry) ListServices(opts ...registry.ListOption) ([]*registry.Service, error) {
+	services, err := r.services("")
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var list []*registry.Service
+	for _, svc := range services {
+		if !seen[svc.Name] {
+			seen[svc.Name] = true
+			list = append(list, &registry.Service{Name: svc.Name})
+		}
+	}
+	return list, nil
+}
+
+func (r *fileRegistry) Watch(opts ...registry.WatchOption) (registry.Watcher, error) {
+	var options registry.WatchOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	return &fileWatcher{r: r, service: options.Service, last: make(map[string]string), exit: make(chan struct{})}, nil
+}
+
+func (r *fileRegistry) String() string {
+	return "file"
+}
+
+// fileWatcher 定期读取文件, 与上一次的内容比较得到变更
+type fileWatcher struct {
+	r        *fileRegistry
+	service  string
+	last     map[string]string
+	lastSvcs map[string]*registry.Service
+	pending  []*registry.Result
+	exit     chan struct{}
+	once     sync.Once
+}
+
+func (w *fileWatcher) Next() (*registry.Result, error) {
+	for {
+		if len(w.pending) > 0 {
+			res := w.pending[0]
+			w.pending = w.pending[1:]
+			return res, nil
+		}
+		select {
+		case <-w.exit:
+			return nil, registry.ErrWatcherStopped
+		case <-time.After(fileWatchInterval):
+		}
+		err := w.diff()
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (w *fileWatcher) diff() error {
+	services, err := w.r.services(w.service)
+	if err != nil {
+		return err
+	}
+	current := make(map[string]string, len(services))
+	for key, svc := range services {
+		b, err := json.Marshal(svc)
+		if err != nil {
+			return err
+		}
+		current[key] = string(b)
+		old, ok := w.last[key]
+		switch {
+		case !ok:
+			w.pending = append(w.pending, &registry.Result{Action: "create", Service: svc})
+		case old != current[key]:
+			w.pending = append(w.pending, &registry.Result{Action: "update", Service: svc})
+		}
+	}
+	for key := range w.last {
+		if _, ok := current[key]; !ok {
+			w.pending = append(w.pending, &registry.Result{Action: "delete", Service: w.lastSvcs[key]})
+		}
+	}
+	w.last = current
+	w.lastSvcs = services
+	return nil
+}
+
+func (w *fileWatcher) Stop() {
+	w.once.Do(func() {
+		close(w.exit)
+	})
+}
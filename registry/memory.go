@@ -0,0 +1,21 @@
+package registry
+
+import (
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/go-micro/v2/registry/memory"
+)
+
+// MemoryReg 进程内registry, 只能发现同一进程中注册的服务
+type MemoryReg struct{}
+
+func newMemoryReg() Regs {
+	return &MemoryReg{}
+}
+
+func (m *MemoryReg) Name() string {
+	return "memory"
+}
+
+func (m *MemoryReg) GetReg() registry.Registry {
+	return memory.NewRegistry()
+}
@@ -1,6 +1,8 @@
 package registry
 
 import (
+	"fmt"
+
 	"github.com/micro/go-micro/v2/registry"
 	"keyayun.com/seal-micro-runner/pkg/config"
 )
@@ -14,12 +16,17 @@ type Regs interface {
 	GetReg() registry.Registry
 }
 
-func NewReg(name string) Regs {
+// NewReg 按名字创建registry: consul, etcd, file或memory
+func NewReg(name string) (Regs, error) {
 	switch name {
 	case "etcd":
-		return newEtcdReg()
+		return newEtcdReg(), nil
 	case "consul":
-		return newConsulReg()
+		return newConsulReg(), nil
+	case "file":
+		return newFileReg(), nil
+	case "memory":
+		return newMemoryReg(), nil
 	}
-	return nil
+	return nil, fmt.Errorf("unknown registry %q", name)
 }